@@ -3,16 +3,22 @@ package client
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"os"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
 	"github.com/jfenske89/jellyfin-cli/pkg/models"
 )
@@ -20,49 +26,186 @@ import (
 // Client defines the interface for interacting with the Jellyfin API
 type Client interface {
 	// ListSessions returns a list of active sessions
-	ListSessions(ctx context.Context, params map[string]string) ([]models.Session, error)
+	ListSessions(ctx context.Context, query SessionQuery) ([]models.Session, error)
 
 	// ListLibraryFolders returns a list of library virtual folders
-	ListLibraryFolders(ctx context.Context, params map[string]string) ([]models.LibraryFolder, error)
+	ListLibraryFolders(ctx context.Context) ([]models.LibraryFolder, error)
+
+	// CreateLibraryFolder adds a library folder holding a type of content read from paths on the server
+	CreateLibraryFolder(ctx context.Context, name, collectionType string, paths []string, refresh bool) error
+
+	// RenameLibraryFolder renames a library folder
+	RenameLibraryFolder(ctx context.Context, name, newName string, refresh bool) error
+
+	// DeleteLibraryFolder removes a library folder, leaving its media files in place
+	DeleteLibraryFolder(ctx context.Context, name string, refresh bool) error
+
+	// AddLibraryPath adds a path on the server to a library folder
+	AddLibraryPath(ctx context.Context, name, path string, refresh bool) error
+
+	// RemoveLibraryPath removes a path from a library folder
+	RemoveLibraryPath(ctx context.Context, name, path string, refresh bool) error
+
+	// UpdateLibraryOptions replaces the settings of a library folder, identified by its item ID
+	UpdateLibraryOptions(ctx context.Context, libraryID string, options models.LibraryOptions) error
+
+	// GetAvailableLibraryOptions returns the providers a library of a collection type can use
+	GetAvailableLibraryOptions(ctx context.Context, collectionType string) (*models.AvailableLibraryOptions, error)
+
+	// ListCultures returns the languages metadata can be fetched in
+	ListCultures(ctx context.Context) ([]models.Culture, error)
+
+	// ListCountries returns the countries metadata can be fetched for
+	ListCountries(ctx context.Context) ([]models.Country, error)
 
 	// ListActivityLogs returns recent activity
-	ListActivityLogs(ctx context.Context, params map[string]string) (*models.ActivityLog, error)
+	ListActivityLogs(ctx context.Context, query ActivityLogQuery) (*models.ActivityLog, error)
+
+	// ListUsers returns the users on the server
+	ListUsers(ctx context.Context, query UserQuery) ([]models.User, error)
+
+	// ListItems returns a page of library items
+	ListItems(ctx context.Context, query ItemQuery) (*models.QueryResult[models.Item], error)
 
 	// Search returns search results
-	Search(ctx context.Context, term string, params map[string]string) (*models.SearchResponse, error)
+	Search(ctx context.Context, query SearchQuery) (*models.SearchResponse, error)
+
+	// PageActivityLogs returns a pager over activity log entries, newest first.
+	// The pager sets the paging fields of the query.
+	PageActivityLogs(query ActivityLogQuery, opts PageOptions) *Pager[models.ActivityLogItem]
+
+	// PageUsers returns a pager over the users on the server
+	PageUsers(query UserQuery, opts PageOptions) *Pager[models.User]
+
+	// PageItems returns a pager over library items
+	PageItems(query ItemQuery, opts PageOptions) *Pager[models.Item]
+
+	// PageSearch returns a pager over search results
+	PageSearch(query SearchQuery, opts PageOptions) *Pager[models.SearchHint]
 
 	// RefreshLibrary initiates a library refresh
 	RefreshLibrary(ctx context.Context) error
+
+	// RefreshItem queues a refresh of an item's metadata and images, such as a library, series or movie
+	RefreshItem(ctx context.Context, itemID string, opts RefreshOptions) error
+
+	// AuthenticateByName logs in with a username and password
+	AuthenticateByName(ctx context.Context, username, password string) (*models.AuthenticationResult, error)
+
+	// Logout revokes the current access token
+	Logout(ctx context.Context) error
+
+	// SendPlaystateCommand sends a playback command such as pause or seek to a session
+	SendPlaystateCommand(ctx context.Context, sessionID string, command models.PlaystateCommand, opts PlaystateOptions) error
+
+	// SendGeneralCommand sends a remote control command such as set volume to a session
+	SendGeneralCommand(ctx context.Context, sessionID string, command models.GeneralCommand) error
+
+	// Play instructs a session to play items
+	Play(ctx context.Context, sessionID string, itemIDs []string, command models.PlayCommand, opts PlayOptions) error
+
+	// SendMessage displays a message on a session's client
+	SendMessage(ctx context.Context, sessionID string, message models.MessageCommand) error
+
+	// DeleteDevice removes a device, revoking its access token and ending its sessions
+	DeleteDevice(ctx context.Context, deviceID string) error
+
+	// ListScheduledTasks returns the scheduled tasks on the server
+	ListScheduledTasks(ctx context.Context, query TaskQuery) ([]models.TaskInfo, error)
+
+	// GetScheduledTask returns a scheduled task, including its progress when running
+	GetScheduledTask(ctx context.Context, taskID string) (*models.TaskInfo, error)
+
+	// StartScheduledTask starts running a scheduled task
+	StartScheduledTask(ctx context.Context, taskID string) error
+
+	// StopScheduledTask cancels a running scheduled task
+	StopScheduledTask(ctx context.Context, taskID string) error
+
+	// UpdateTaskTriggers replaces the triggers of a scheduled task
+	UpdateTaskTriggers(ctx context.Context, taskID string, triggers []models.TaskTrigger) error
+
+	// GetPublicSystemInfo returns the server information available without authentication
+	GetPublicSystemInfo(ctx context.Context) (*models.PublicSystemInfo, error)
+
+	// GetSystemInfo returns the full server information, which requires an administrator
+	GetSystemInfo(ctx context.Context) (*models.SystemInfo, error)
+
+	// Ping checks that the server is responding, returning the name it answers with
+	Ping(ctx context.Context) (string, error)
+
+	// StreamEvents delivers server events to handler until the context is cancelled
+	StreamEvents(ctx context.Context, subscriptions []models.EventType, handler func(models.Event) error) error
 }
 
+// idPattern matches Jellyfin IDs, which are GUIDs with or without dashes
+var idPattern = regexp.MustCompile(`^(?:[0-9a-fA-F]{32}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// ClientName and ClientVersion identify the CLI in the authorization header
+var (
+	ClientName    = "jellyfin-cli"
+	ClientVersion = "dev"
+)
+
 // JellyfinClient is the implementation of the Client interface
 type JellyfinClient struct {
 	config     models.JellyfinConfig
 	httpClient *http.Client
+	transport  *http.Transport
+	limiter    *rate.Limiter
 	logger     *zap.SugaredLogger
+
+	// Debugging options
+	trace  bool
+	har    *HARRecorder
+	dryRun io.Writer
 }
 
-// NewClient creates a new Jellyfin API client
-func NewClient(config models.JellyfinConfig, logger *zap.SugaredLogger) Client {
-	return &JellyfinClient{
+// NewClient creates a new Jellyfin API client.
+// It fails when the TLS or proxy settings can't be used.
+func NewClient(config models.JellyfinConfig, logger *zap.SugaredLogger, opts ...Option) (Client, error) {
+	transport, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	// Without a rate limit, requests are sent as fast as they are made
+	var limiter *rate.Limiter
+	if config.RateLimit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(config.RateLimit), max(int(math.Ceil(config.RateLimit)), 1))
+	}
+
+	c := &JellyfinClient{
 		config: config,
 		httpClient: &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: config.SkipSSLVerify,
-				},
-			},
-			Timeout: 30 * time.Second,
+			Transport: transport,
+			Timeout:   timeout,
 		},
-		logger: logger,
+		transport: transport,
+		limiter:   limiter,
+		logger:    logger,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.trace || c.har != nil {
+		c.httpClient.Transport = &traceTransport{next: transport, trace: c.trace, har: c.har, log: logger.Debugw}
 	}
+
+	return c, nil
 }
 
 // ListSessions retrieves active sessions from the Jellyfin server
-func (c *JellyfinClient) ListSessions(ctx context.Context, params map[string]string) ([]models.Session, error) {
+func (c *JellyfinClient) ListSessions(ctx context.Context, query SessionQuery) ([]models.Session, error) {
 	var sessions []models.Session
 
-	err := c.doRequest(ctx, http.MethodGet, "Sessions", params, nil, &sessions)
+	err := c.doRequest(ctx, http.MethodGet, "Sessions", query, nil, &sessions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sessions: %w", err)
 	}
@@ -71,10 +214,10 @@ func (c *JellyfinClient) ListSessions(ctx context.Context, params map[string]str
 }
 
 // ListLibraryFolders retrieves library folders from the Jellyfin server
-func (c *JellyfinClient) ListLibraryFolders(ctx context.Context, params map[string]string) ([]models.LibraryFolder, error) {
+func (c *JellyfinClient) ListLibraryFolders(ctx context.Context) ([]models.LibraryFolder, error) {
 	var folders []models.LibraryFolder
 
-	err := c.doRequest(ctx, http.MethodGet, "Library/VirtualFolders", params, nil, &folders)
+	err := c.doRequest(ctx, http.MethodGet, "Library/VirtualFolders", nil, nil, &folders)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list library folders: %w", err)
 	}
@@ -82,11 +225,272 @@ func (c *JellyfinClient) ListLibraryFolders(ctx context.Context, params map[stri
 	return folders, nil
 }
 
+// CreateLibraryFolder adds a library folder to the Jellyfin server
+func (c *JellyfinClient) CreateLibraryFolder(ctx context.Context, name, collectionType string, paths []string, refresh bool) error {
+	query := libraryFolderQuery{Name: name, CollectionType: collectionType, RefreshLibrary: refresh}
+
+	// Paths go in the body, since the query can't hold paths containing commas
+	var body struct {
+		LibraryOptions struct {
+			PathInfos []models.MediaPathInfo `json:"PathInfos"`
+		} `json:"LibraryOptions"`
+	}
+	body.LibraryOptions.PathInfos = make([]models.MediaPathInfo, 0, len(paths))
+	for _, path := range paths {
+		body.LibraryOptions.PathInfos = append(body.LibraryOptions.PathInfos, models.MediaPathInfo{Path: path})
+	}
+
+	err := c.doRequest(ctx, http.MethodPost, "Library/VirtualFolders", query, body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create library folder: %w", err)
+	}
+
+	return nil
+}
+
+// RenameLibraryFolder renames a library folder on the Jellyfin server
+func (c *JellyfinClient) RenameLibraryFolder(ctx context.Context, name, newName string, refresh bool) error {
+	if newName == "" {
+		return fmt.Errorf("new library name is required")
+	}
+
+	query := libraryFolderQuery{Name: name, NewName: newName, RefreshLibrary: refresh}
+
+	err := c.doRequest(ctx, http.MethodPost, "Library/VirtualFolders/Name", query, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to rename library folder: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteLibraryFolder removes a library folder from the Jellyfin server
+func (c *JellyfinClient) DeleteLibraryFolder(ctx context.Context, name string, refresh bool) error {
+	query := libraryFolderQuery{Name: name, RefreshLibrary: refresh}
+
+	err := c.doRequest(ctx, http.MethodDelete, "Library/VirtualFolders", query, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete library folder: %w", err)
+	}
+
+	return nil
+}
+
+// AddLibraryPath adds a path to a library folder on the Jellyfin server
+func (c *JellyfinClient) AddLibraryPath(ctx context.Context, name, path string, refresh bool) error {
+	if path == "" {
+		return fmt.Errorf("library path is required")
+	}
+
+	query := libraryFolderQuery{Name: name, RefreshLibrary: refresh}
+	body := map[string]string{
+		"Name": name,
+		"Path": path,
+	}
+
+	err := c.doRequest(ctx, http.MethodPost, "Library/VirtualFolders/Paths", query, body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to add library path: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveLibraryPath removes a path from a library folder on the Jellyfin server
+func (c *JellyfinClient) RemoveLibraryPath(ctx context.Context, name, path string, refresh bool) error {
+	if path == "" {
+		return fmt.Errorf("library path is required")
+	}
+
+	query := libraryFolderQuery{Name: name, Path: path, RefreshLibrary: refresh}
+
+	err := c.doRequest(ctx, http.MethodDelete, "Library/VirtualFolders/Paths", query, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove library path: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLibraryOptions replaces the settings of a library folder on the Jellyfin server
+func (c *JellyfinClient) UpdateLibraryOptions(ctx context.Context, libraryID string, options models.LibraryOptions) error {
+	if err := validateID("library", libraryID); err != nil {
+		return err
+	}
+
+	body := struct {
+		ID             string                `json:"Id"`
+		LibraryOptions models.LibraryOptions `json:"LibraryOptions"`
+	}{libraryID, options}
+
+	err := c.doRequest(ctx, http.MethodPost, "Library/VirtualFolders/LibraryOptions", nil, body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update library options: %w", err)
+	}
+
+	return nil
+}
+
+// GetAvailableLibraryOptions retrieves the providers a library can use from the Jellyfin server
+func (c *JellyfinClient) GetAvailableLibraryOptions(ctx context.Context, collectionType string) (*models.AvailableLibraryOptions, error) {
+	query := availableOptionsQuery{LibraryContentType: collectionType}
+
+	var options models.AvailableLibraryOptions
+
+	err := c.doRequest(ctx, http.MethodGet, "Libraries/AvailableOptions", query, nil, &options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available library options: %w", err)
+	}
+
+	return &options, nil
+}
+
+// ListCultures retrieves the languages known to the Jellyfin server
+func (c *JellyfinClient) ListCultures(ctx context.Context) ([]models.Culture, error) {
+	var cultures []models.Culture
+
+	err := c.doRequest(ctx, http.MethodGet, "Localization/Cultures", nil, nil, &cultures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cultures: %w", err)
+	}
+
+	return cultures, nil
+}
+
+// ListCountries retrieves the countries known to the Jellyfin server
+func (c *JellyfinClient) ListCountries(ctx context.Context) ([]models.Country, error) {
+	var countries []models.Country
+
+	err := c.doRequest(ctx, http.MethodGet, "Localization/Countries", nil, nil, &countries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list countries: %w", err)
+	}
+
+	return countries, nil
+}
+
+// ListScheduledTasks retrieves the scheduled tasks from the Jellyfin server
+func (c *JellyfinClient) ListScheduledTasks(ctx context.Context, query TaskQuery) ([]models.TaskInfo, error) {
+	var tasks []models.TaskInfo
+
+	err := c.doRequest(ctx, http.MethodGet, "ScheduledTasks", query, nil, &tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetScheduledTask retrieves a scheduled task
+func (c *JellyfinClient) GetScheduledTask(ctx context.Context, taskID string) (*models.TaskInfo, error) {
+	if err := validateID("task", taskID); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("ScheduledTasks/%s", url.PathEscape(taskID))
+
+	var task models.TaskInfo
+
+	err := c.doRequest(ctx, http.MethodGet, endpoint, nil, nil, &task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// StartScheduledTask starts a scheduled task
+func (c *JellyfinClient) StartScheduledTask(ctx context.Context, taskID string) error {
+	if err := validateID("task", taskID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("ScheduledTasks/Running/%s", url.PathEscape(taskID))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start scheduled task: %w", err)
+	}
+
+	return nil
+}
+
+// StopScheduledTask cancels a running scheduled task
+func (c *JellyfinClient) StopScheduledTask(ctx context.Context, taskID string) error {
+	if err := validateID("task", taskID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("ScheduledTasks/Running/%s", url.PathEscape(taskID))
+
+	err := c.doRequest(ctx, http.MethodDelete, endpoint, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to stop scheduled task: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTaskTriggers replaces the triggers of a scheduled task
+func (c *JellyfinClient) UpdateTaskTriggers(ctx context.Context, taskID string, triggers []models.TaskTrigger) error {
+	if err := validateID("task", taskID); err != nil {
+		return err
+	}
+	if triggers == nil {
+		triggers = []models.TaskTrigger{}
+	}
+
+	endpoint := fmt.Sprintf("ScheduledTasks/%s/Triggers", url.PathEscape(taskID))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, nil, triggers, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update task triggers: %w", err)
+	}
+
+	return nil
+}
+
+// GetPublicSystemInfo retrieves the public server information
+func (c *JellyfinClient) GetPublicSystemInfo(ctx context.Context) (*models.PublicSystemInfo, error) {
+	var info models.PublicSystemInfo
+
+	err := c.doRequest(ctx, http.MethodGet, "System/Info/Public", nil, nil, &info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public system info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// GetSystemInfo retrieves the full server information
+func (c *JellyfinClient) GetSystemInfo(ctx context.Context) (*models.SystemInfo, error) {
+	var info models.SystemInfo
+
+	err := c.doRequest(ctx, http.MethodGet, "System/Info", nil, nil, &info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// Ping checks that the server is responding
+func (c *JellyfinClient) Ping(ctx context.Context) (string, error) {
+	var name string
+
+	err := c.doRequest(ctx, http.MethodGet, "System/Ping", nil, nil, &name)
+	if err != nil {
+		return "", fmt.Errorf("failed to ping server: %w", err)
+	}
+
+	return name, nil
+}
+
 // ListActivityLogs retrieves activity logs from the Jellyfin server
-func (c *JellyfinClient) ListActivityLogs(ctx context.Context, params map[string]string) (*models.ActivityLog, error) {
+func (c *JellyfinClient) ListActivityLogs(ctx context.Context, query ActivityLogQuery) (*models.ActivityLog, error) {
 	var logs models.ActivityLog
 
-	err := c.doRequest(ctx, http.MethodGet, "System/ActivityLog/Entries", params, nil, &logs)
+	err := c.doRequest(ctx, http.MethodGet, "System/ActivityLog/Entries", query, nil, &logs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list activity logs: %w", err)
 	}
@@ -94,16 +498,40 @@ func (c *JellyfinClient) ListActivityLogs(ctx context.Context, params map[string
 	return &logs, nil
 }
 
-// Search searches the Jellyfin server for content
-func (c *JellyfinClient) Search(ctx context.Context, term string, params map[string]string) (*models.SearchResponse, error) {
-	if params == nil {
-		params = make(map[string]string)
+// ListUsers retrieves users from the Jellyfin server
+func (c *JellyfinClient) ListUsers(ctx context.Context, query UserQuery) ([]models.User, error) {
+	var users []models.User
+
+	err := c.doRequest(ctx, http.MethodGet, "Users", query, nil, &users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
-	params["searchTerm"] = term
 
+	return users, nil
+}
+
+// ListItems retrieves a page of library items from the Jellyfin server.
+// Items are queried as the configured user when one is known.
+func (c *JellyfinClient) ListItems(ctx context.Context, query ItemQuery) (*models.QueryResult[models.Item], error) {
+	if query.UserID == "" {
+		query.UserID = c.config.UserID
+	}
+
+	var result models.QueryResult[models.Item]
+
+	err := c.doRequest(ctx, http.MethodGet, "Items", query, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list items: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Search searches the Jellyfin server for content
+func (c *JellyfinClient) Search(ctx context.Context, query SearchQuery) (*models.SearchResponse, error) {
 	var response models.SearchResponse
 
-	err := c.doRequest(ctx, http.MethodGet, "Search/Hints", params, nil, &response)
+	err := c.doRequest(ctx, http.MethodGet, "Search/Hints", query, nil, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search: %w", err)
 	}
@@ -111,6 +539,67 @@ func (c *JellyfinClient) Search(ctx context.Context, term string, params map[str
 	return &response, nil
 }
 
+// PageActivityLogs returns a pager over activity log entries
+func (c *JellyfinClient) PageActivityLogs(query ActivityLogQuery, opts PageOptions) *Pager[models.ActivityLogItem] {
+	return NewPager(func(ctx context.Context, startIndex, limit int) ([]models.ActivityLogItem, int, error) {
+		// Pages are fetched concurrently, so each gets its own copy of the query
+		q := query
+		q.StartIndex, q.Limit = startIndex, limit
+		logs, err := c.ListActivityLogs(ctx, q)
+		if err != nil {
+			return nil, 0, err
+		}
+		return logs.Items, logs.TotalCount, nil
+	}, opts)
+}
+
+// PageUsers returns a pager over users. The users endpoint isn't paginated,
+// so the complete list is fetched once and each page is cut from it.
+func (c *JellyfinClient) PageUsers(query UserQuery, opts PageOptions) *Pager[models.User] {
+	var (
+		once    sync.Once
+		users   []models.User
+		listErr error
+	)
+
+	return NewPager(func(ctx context.Context, startIndex, limit int) ([]models.User, int, error) {
+		once.Do(func() {
+			users, listErr = c.ListUsers(ctx, query)
+		})
+
+		if listErr != nil {
+			return nil, 0, listErr
+		}
+		return slicePage(users, startIndex, limit), len(users), nil
+	}, opts)
+}
+
+// PageItems returns a pager over library items
+func (c *JellyfinClient) PageItems(query ItemQuery, opts PageOptions) *Pager[models.Item] {
+	return NewPager(func(ctx context.Context, startIndex, limit int) ([]models.Item, int, error) {
+		q := query
+		q.StartIndex, q.Limit = startIndex, limit
+		result, err := c.ListItems(ctx, q)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Items, result.TotalRecordCount, nil
+	}, opts)
+}
+
+// PageSearch returns a pager over search results
+func (c *JellyfinClient) PageSearch(query SearchQuery, opts PageOptions) *Pager[models.SearchHint] {
+	return NewPager(func(ctx context.Context, startIndex, limit int) ([]models.SearchHint, int, error) {
+		q := query
+		q.StartIndex, q.Limit = startIndex, limit
+		response, err := c.Search(ctx, q)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.SearchHints, response.TotalHints, nil
+	}, opts)
+}
+
 // RefreshLibrary initiates a library refresh on the Jellyfin server
 func (c *JellyfinClient) RefreshLibrary(ctx context.Context) error {
 	err := c.doRequest(ctx, http.MethodPost, "Library/Refresh", nil, nil, nil)
@@ -121,17 +610,165 @@ func (c *JellyfinClient) RefreshLibrary(ctx context.Context) error {
 	return nil
 }
 
+// RefreshItem queues a refresh of an item's metadata and images on the Jellyfin server
+func (c *JellyfinClient) RefreshItem(ctx context.Context, itemID string, opts RefreshOptions) error {
+	if err := validateID("item", itemID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("Items/%s/Refresh", url.PathEscape(itemID))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, opts, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to refresh item: %w", err)
+	}
+
+	return nil
+}
+
+// AuthenticateByName logs in to the Jellyfin server with a username and password
+func (c *JellyfinClient) AuthenticateByName(ctx context.Context, username, password string) (*models.AuthenticationResult, error) {
+	body := map[string]string{
+		"Username": username,
+		"Pw":       password,
+	}
+
+	var result models.AuthenticationResult
+
+	err := c.doRequest(ctx, http.MethodPost, "Users/AuthenticateByName", nil, body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Logout ends the session associated with the current access token
+func (c *JellyfinClient) Logout(ctx context.Context) error {
+	err := c.doRequest(ctx, http.MethodPost, "Sessions/Logout", nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+
+	return nil
+}
+
+// SendPlaystateCommand sends a playback command to a session
+func (c *JellyfinClient) SendPlaystateCommand(ctx context.Context, sessionID string, command models.PlaystateCommand, opts PlaystateOptions) error {
+	if err := validateID("session", sessionID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("Sessions/%s/Playing/%s", url.PathEscape(sessionID), url.PathEscape(string(command)))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, opts, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send %s command: %w", command, err)
+	}
+
+	return nil
+}
+
+// SendGeneralCommand sends a remote control command to a session
+func (c *JellyfinClient) SendGeneralCommand(ctx context.Context, sessionID string, command models.GeneralCommand) error {
+	if err := validateID("session", sessionID); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("Sessions/%s/Command", url.PathEscape(sessionID))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, nil, command, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send %s command: %w", command.Name, err)
+	}
+
+	return nil
+}
+
+// Play instructs a session to play items, now or queued
+func (c *JellyfinClient) Play(ctx context.Context, sessionID string, itemIDs []string, command models.PlayCommand, opts PlayOptions) error {
+	if err := validateID("session", sessionID); err != nil {
+		return err
+	}
+	if len(itemIDs) == 0 {
+		return fmt.Errorf("at least one item ID is required")
+	}
+	for _, itemID := range itemIDs {
+		if err := validateID("item", itemID); err != nil {
+			return err
+		}
+	}
+
+	query := playQuery{PlayCommand: command, ItemIDs: itemIDs, PlayOptions: opts}
+	endpoint := fmt.Sprintf("Sessions/%s/Playing", url.PathEscape(sessionID))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, query, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to play items: %w", err)
+	}
+
+	return nil
+}
+
+// SendMessage displays a message on a session's client
+func (c *JellyfinClient) SendMessage(ctx context.Context, sessionID string, message models.MessageCommand) error {
+	if err := validateID("session", sessionID); err != nil {
+		return err
+	}
+	if message.Text == "" {
+		return fmt.Errorf("message text is required")
+	}
+
+	endpoint := fmt.Sprintf("Sessions/%s/Message", url.PathEscape(sessionID))
+
+	err := c.doRequest(ctx, http.MethodPost, endpoint, nil, message, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDevice removes a device from the server, which revokes its access token
+func (c *JellyfinClient) DeleteDevice(ctx context.Context, deviceID string) error {
+	if deviceID == "" {
+		return fmt.Errorf("device ID is required")
+	}
+
+	err := c.doRequest(ctx, http.MethodDelete, "Devices", deviceQuery{ID: deviceID}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete device: %w", err)
+	}
+
+	return nil
+}
+
+// validateID checks that an ID has the format of a Jellyfin GUID
+func validateID(kind, id string) error {
+	if id == "" {
+		return fmt.Errorf("%s ID is required", kind)
+	}
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("invalid %s ID %q", kind, id)
+	}
+
+	return nil
+}
+
 // doRequest handles the HTTP request to the Jellyfin API
 func (c *JellyfinClient) doRequest(
 	ctx context.Context,
 	method string,
 	endpoint string,
-	params map[string]string,
+	query any,
 	body interface{},
 	result interface{},
 ) error {
 	// Build the URL with any query parameters
-	fullEndpoint := c.appendQueryParams(endpoint, params)
+	fullEndpoint, err := c.appendQueryParams(endpoint, query)
+	if err != nil {
+		return err
+	}
 
 	// Get the full URL for the request
 	reqURL, err := c.buildURL(fullEndpoint)
@@ -148,21 +785,10 @@ func (c *JellyfinClient) doRequest(
 		}
 	}
 
-	// Create the request
-	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), bytes.NewReader(bodyBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add headers
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("X-Emby-Token", c.config.Token)
-
 	// Execute the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.execute(ctx, method, reqURL, bodyBytes)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -173,11 +799,11 @@ func (c *JellyfinClient) doRequest(
 	// Check for error status codes
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes)
 	}
 
 	// If no result is expected, return
-	if result == nil {
+	if result == nil || resp.StatusCode == http.StatusNoContent {
 		return nil
 	}
 
@@ -195,18 +821,114 @@ func (c *JellyfinClient) doRequest(
 	return nil
 }
 
-// appendQueryParams appends query parameters to an endpoint
-func (c *JellyfinClient) appendQueryParams(endpoint string, params map[string]string) string {
-	if len(params) == 0 {
-		return endpoint
+// execute sends a request, waiting for the rate limiter before each attempt.
+// Idempotent requests that fail with a transient error are retried with backoff,
+// up to the configured number of retries.
+func (c *JellyfinClient) execute(ctx context.Context, method string, reqURL *url.URL, body []byte) (*http.Response, error) {
+	retries := retriesFor(ctx, method, c.config.Retries)
+
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+			}
+		}
+
+		// Create the request
+		req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Add headers
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Accept", "application/json")
+		req.Header.Add("X-Emby-Authorization", c.authorizationHeader())
+		c.addHeaders(req.Header)
+
+		// In a dry run, show requests that would change the server instead of sending them
+		if c.dryRun != nil && !isReadOnly(method) {
+			printDryRun(c.dryRun, req, body)
+			return dryRunResponse(req), nil
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if attempt >= retries || !shouldRetry(ctx, resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute request: %w", err)
+			}
+			return resp, nil
+		}
+
+		delay := retryDelay(attempt, resp)
+		if err != nil {
+			c.logger.Warnw("Request failed, retrying", "method", method, "url", redactURL(reqURL), "error", err, "attempt", attempt+1, "delay", delay)
+		} else {
+			c.logger.Warnw("Request failed, retrying", "method", method, "url", redactURL(reqURL), "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
+
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			if err := resp.Body.Close(); err != nil {
+				c.logger.Warnw("failed to close response body", "error", err)
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("failed to execute request: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// authorizationHeader builds the MediaBrowser authorization header identifying this client
+func (c *JellyfinClient) authorizationHeader() string {
+	fields := []string{
+		fmt.Sprintf(`Client="%s"`, url.PathEscape(ClientName)),
+		fmt.Sprintf(`Device="%s"`, url.PathEscape(deviceName())),
+		fmt.Sprintf(`DeviceId="%s"`, url.PathEscape(c.deviceID())),
+		fmt.Sprintf(`Version="%s"`, url.PathEscape(ClientVersion)),
+	}
+	if c.config.Token != "" {
+		fields = append(fields, fmt.Sprintf(`Token="%s"`, url.PathEscape(c.config.Token)))
+	}
+
+	return "MediaBrowser " + strings.Join(fields, ", ")
+}
+
+// deviceID returns the configured device ID, or one derived from the hostname
+func (c *JellyfinClient) deviceID() string {
+	if c.config.DeviceID != "" {
+		return c.config.DeviceID
+	}
+
+	sum := sha256.Sum256([]byte(ClientName + "@" + deviceName()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// deviceName returns the name this machine is reported as
+func deviceName() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return ClientName
 	}
 
-	values := url.Values{}
-	for key, value := range params {
-		values.Add(key, value)
+	return hostname
+}
+
+// appendQueryParams validates a query struct and appends its parameters to an endpoint
+func (c *JellyfinClient) appendQueryParams(endpoint string, query any) (string, error) {
+	values, err := encodeQuery(query)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return endpoint, nil
 	}
 
-	return fmt.Sprintf("%s?%s", endpoint, values.Encode())
+	return fmt.Sprintf("%s?%s", endpoint, values.Encode()), nil
 }
 
 // buildURL builds the full URL for an API request
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// redactedQueryParams are query parameters that carry credentials
+var redactedQueryParams = []string{"api_key", "apikey", "token"}
+
+// APIError is returned when the Jellyfin API responds with a non-2xx status.
+// Jellyfin reports many errors as RFC 7807 problem details, whose fields are
+// decoded when present. The other fields are never taken from the body.
+type APIError struct {
+	// Method is the HTTP method of the failed request
+	Method string `json:"-"`
+
+	// URL is the requested URL, with any credentials redacted
+	URL string `json:"-"`
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
+
+	// Problem details fields
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Detail   string              `json:"detail"`
+	Instance string              `json:"instance"`
+	TraceID  string              `json:"traceId"`
+	Errors   map[string][]string `json:"errors"`
+
+	// Body is the raw response body
+	Body []byte `json:"-"`
+}
+
+// newAPIError builds an APIError from a failed response and its body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Body: body}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = redactURL(resp.Request.URL)
+	}
+
+	// Problem details are optional, so a body that isn't one is simply kept raw
+	_ = json.Unmarshal(body, apiErr)
+
+	return apiErr
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status())
+	if detail := e.Message(); detail != "" {
+		message += ": " + detail
+	}
+
+	return message
+}
+
+// Status returns the status code and its text, e.g. "404 Not Found"
+func (e *APIError) Status() string {
+	return strings.TrimSpace(fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)))
+}
+
+// Message returns the most descriptive explanation the server gave for the error
+func (e *APIError) Message() string {
+	var parts []string
+	switch {
+	case e.Detail != "":
+		parts = append(parts, e.Detail)
+	case e.Title != "":
+		parts = append(parts, e.Title)
+	default:
+		if body := strings.TrimSpace(string(e.Body)); body != "" && !strings.HasPrefix(body, "{") {
+			parts = append(parts, body)
+		}
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(e.Errors)) {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, strings.Join(e.Errors[field], ", ")))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// IsUnauthorized reports whether err is an API error with status 401,
+// meaning the token is missing, invalid or expired
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an API error with status 403,
+// meaning the user isn't allowed to perform the request
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is an API error with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsServerError reports whether err is an API error with a 5xx status
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+// hasStatus reports whether err is an API error with the given status code
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// redactURL formats a URL with credentials in its query string or user info replaced
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+	redacted.User = nil
+
+	query := redacted.Query()
+	changed := false
+	for key := range query {
+		for _, param := range redactedQueryParams {
+			if strings.EqualFold(key, param) {
+				query.Set(key, "REDACTED")
+				changed = true
+			}
+		}
+	}
+	if changed {
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.String()
+}
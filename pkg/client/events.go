@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+const (
+	// eventRetryMin and eventRetryMax bound the delay between reconnect attempts
+	eventRetryMin = time.Second
+	eventRetryMax = time.Minute
+
+	// defaultKeepAlive is the keep-alive timeout assumed until the server announces one
+	defaultKeepAlive = 60 * time.Second
+
+	// eventWriteTimeout limits how long sending a message may block
+	eventWriteTimeout = 10 * time.Second
+
+	// subscriptionInterval is the "initial delay,interval" in milliseconds sent when starting a feed
+	subscriptionInterval = "0,1500"
+)
+
+// outgoingMessage is a message sent to the server over the WebSocket connection
+type outgoingMessage struct {
+	MessageType string `json:"MessageType"`
+	Data        any    `json:"Data,omitempty"`
+}
+
+// permanentError stops the event stream instead of reconnecting
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// StreamEvents connects to the server's WebSocket and passes each event to handler
+// until the context is cancelled. Keep-alive messages are answered and not passed on.
+// Lost connections are re-established with exponential backoff; the stream stops
+// when the server rejects the credentials or handler returns an error.
+func (c *JellyfinClient) StreamEvents(ctx context.Context, subscriptions []models.EventType, handler func(models.Event) error) error {
+	delay := eventRetryMin
+
+	for {
+		connected, err := c.streamEventsOnce(ctx, subscriptions, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		// Start over with a short delay once a connection has worked
+		if connected {
+			delay = eventRetryMin
+		}
+
+		c.logger.Warnw("Event stream disconnected, reconnecting", "error", err, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, eventRetryMax)
+	}
+}
+
+// streamEventsOnce runs a single WebSocket connection until it fails.
+// It reports whether the connection was established.
+func (c *JellyfinClient) streamEventsOnce(ctx context.Context, subscriptions []models.EventType, handler func(models.Event) error) (bool, error) {
+	socketURL, err := c.buildSocketURL()
+	if err != nil {
+		return false, &permanentError{err}
+	}
+
+	header := http.Header{}
+	header.Add("X-Emby-Authorization", c.authorizationHeader())
+	c.addHeaders(header)
+
+	start := time.Now()
+	conn, resp, err := c.websocketDialer().DialContext(ctx, socketURL.String(), header)
+	var body []byte
+	if err != nil && resp != nil {
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body)
+	}
+	c.traceHandshake(socketURL, header, resp, body, start, err)
+
+	if err != nil {
+		if resp != nil {
+			// A rejected handshake is reported like any other failed API request
+			err = newAPIError(resp, body)
+			if IsUnauthorized(err) || IsForbidden(err) {
+				return false, &permanentError{fmt.Errorf("event stream rejected: %w", err)}
+			}
+		}
+		return false, fmt.Errorf("failed to connect to event stream: %w", err)
+	}
+
+	// Closing the connection when the context ends unblocks the read loop
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-connCtx.Done()
+		if err := conn.Close(); err != nil {
+			c.logger.Debugw("failed to close event stream", "error", err)
+		}
+	}()
+
+	var writeMu sync.Mutex
+	send := func(messageType string, data any) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+
+		if err := conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout)); err != nil {
+			return err
+		}
+		return conn.WriteJSON(outgoingMessage{MessageType: messageType, Data: data})
+	}
+
+	for _, subscription := range subscriptions {
+		if err := send(string(subscription)+"Start", subscriptionInterval); err != nil {
+			return true, fmt.Errorf("failed to subscribe to %s events: %w", subscription, err)
+		}
+	}
+
+	keepAlive := defaultKeepAlive
+	keepingAlive := false
+
+	for {
+		// A silent connection is considered lost after missing two keep-alives
+		if err := conn.SetReadDeadline(time.Now().Add(2 * keepAlive)); err != nil {
+			return true, err
+		}
+
+		var event models.Event
+		if err := conn.ReadJSON(&event); err != nil {
+			if ctx.Err() != nil {
+				return true, nil
+			}
+			return true, fmt.Errorf("failed to read event: %w", err)
+		}
+
+		switch event.MessageType {
+		case models.EventForceKeepAlive:
+			var seconds int
+			if err := json.Unmarshal(event.Data, &seconds); err == nil && seconds > 0 {
+				keepAlive = time.Duration(seconds) * time.Second
+			}
+			if !keepingAlive {
+				keepingAlive = true
+				go c.keepAlive(connCtx, keepAlive/2, send)
+			}
+			continue
+
+		case models.EventKeepAlive:
+			continue
+		}
+
+		if err := handler(event); err != nil {
+			return true, &permanentError{err}
+		}
+	}
+}
+
+// traceHandshake logs and records the WebSocket handshake, which the dialer sends
+// on its own connection rather than through the HTTP client's transport
+func (c *JellyfinClient) traceHandshake(socketURL *url.URL, header http.Header, resp *http.Response, body []byte, start time.Time, err error) {
+	if !c.trace && c.har == nil {
+		return
+	}
+	elapsed := time.Since(start)
+
+	if c.trace {
+		switch {
+		case resp != nil:
+			c.logger.Debugw("WebSocket handshake", "url", redactURL(socketURL), "headers", redactHeaders(header), "status", resp.StatusCode, "duration", elapsed, "response_headers", redactHeaders(resp.Header), "body", truncateBody(redactBody(body)))
+		default:
+			c.logger.Debugw("WebSocket handshake failed", "url", redactURL(socketURL), "headers", redactHeaders(header), "duration", elapsed, "error", err)
+		}
+	}
+	if c.har != nil {
+		req := &http.Request{Method: http.MethodGet, URL: socketURL, Proto: "HTTP/1.1", Header: header}
+		c.har.record(req, nil, resp, redactBody(body), start, elapsed, err)
+	}
+}
+
+// keepAlive sends keep-alive messages at the given interval until the context ends
+func (c *JellyfinClient) keepAlive(ctx context.Context, interval time.Duration, send func(string, any) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := send(string(models.EventKeepAlive), nil); err != nil {
+			c.logger.Debugw("failed to send keep-alive", "error", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// websocketDialer returns a dialer sharing the HTTP client's TLS and proxy settings
+func (c *JellyfinClient) websocketDialer() *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = c.transport.TLSClientConfig
+	dialer.Proxy = c.transport.Proxy
+	dialer.NetDialContext = c.transport.DialContext
+
+	return &dialer
+}
+
+// buildSocketURL builds the WebSocket URL, authenticating with the access token
+func (c *JellyfinClient) buildSocketURL() (*url.URL, error) {
+	baseURL, err := url.Parse(c.config.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	socketPath := "/socket"
+	if strings.HasSuffix(baseURL.Path, "/") {
+		socketPath = "socket"
+	}
+
+	socketURL, err := baseURL.Parse(socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	switch socketURL.Scheme {
+	case "https":
+		socketURL.Scheme = "wss"
+	case "http":
+		socketURL.Scheme = "ws"
+	default:
+		return nil, fmt.Errorf("invalid base URL scheme %q", socketURL.Scheme)
+	}
+
+	values := url.Values{}
+	values.Add("deviceId", c.deviceID())
+	if c.config.Token != "" {
+		values.Add("api_key", c.config.Token)
+	}
+	socketURL.RawQuery = values.Encode()
+
+	return socketURL, nil
+}
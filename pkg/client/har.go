@@ -0,0 +1,198 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HARRecorder collects HTTP exchanges and writes them as an HTTP Archive (HAR) file,
+// which browsers' developer tools and most HTTP debugging tools can open.
+// Credentials in headers, URLs and bodies are redacted.
+type HARRecorder struct {
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// harLog is the root of a HAR file
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// NewHARRecorder creates an empty HAR recorder
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// record adds an exchange to the archive. A failed request has a nil response and an error.
+// The bodies are expected to be redacted already.
+func (r *HARRecorder) record(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, start time.Time, elapsed time.Duration, err error) {
+	millis := float64(elapsed.Microseconds()) / 1000
+
+	entry := harEntry{
+		StartedDateTime: start,
+		Time:            millis,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         redactURL(req.URL),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(redactHeaders(req.Header)),
+			QueryString: make([]harNameValue, 0),
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Headers:     make([]harNameValue, 0),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: harTimings{Wait: millis},
+	}
+
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	for i, param := range entry.Request.QueryString {
+		if slices.ContainsFunc(redactedQueryParams, func(name string) bool { return strings.EqualFold(param.Name, name) }) {
+			entry.Request.QueryString[i].Value = "REDACTED"
+		}
+	}
+	slices.SortStableFunc(entry.Request.QueryString, func(a, b harNameValue) int { return strings.Compare(a.Name, b.Name) })
+
+	if len(reqBody) > 0 {
+		entry.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: string(reqBody)}
+	}
+
+	if resp != nil {
+		entry.Response = harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     harHeaders(redactHeaders(resp.Header)),
+			Content: harContent{
+				Size:     len(respBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     string(respBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+// WriteFile writes the recorded exchanges to a HAR file, in the order they started
+func (r *HARRecorder) WriteFile(path string) error {
+	r.mu.Lock()
+	var archive harLog
+	archive.Log.Version = "1.2"
+	archive.Log.Creator = harCreator{Name: ClientName, Version: ClientVersion}
+	archive.Log.Entries = slices.Clone(r.entries)
+	r.mu.Unlock()
+
+	if archive.Log.Entries == nil {
+		archive.Log.Entries = make([]harEntry, 0)
+	}
+	slices.SortStableFunc(archive.Log.Entries, func(a, b harEntry) int { return a.StartedDateTime.Compare(b.StartedDateTime) })
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode HAR file: %w", err)
+	}
+
+	// The archive holds response bodies, so keep it private like the config file
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write HAR file: %w", err)
+	}
+
+	return nil
+}
+
+// harHeaders converts headers to HAR name/value pairs, sorted by name
+func harHeaders(header http.Header) []harNameValue {
+	pairs := make([]harNameValue, 0, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			pairs = append(pairs, harNameValue{Name: name, Value: value})
+		}
+	}
+	slices.SortStableFunc(pairs, func(a, b harNameValue) int { return strings.Compare(a.Name, b.Name) })
+
+	return pairs
+}
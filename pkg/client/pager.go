@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+const (
+	// DefaultPageSize is the number of items requested per page when none is configured
+	DefaultPageSize = 100
+
+	// DefaultPrefetch is the number of pages commands fetch ahead of the one being read
+	DefaultPrefetch = 2
+)
+
+// PageFunc fetches up to limit items starting at startIndex,
+// returning them along with the total number of items available
+type PageFunc[T any] func(ctx context.Context, startIndex, limit int) (items []T, total int, err error)
+
+// PageOptions configures a Pager
+type PageOptions struct {
+	// StartIndex is the index of the first item to return
+	StartIndex int
+
+	// PageSize is the number of items requested at a time, DefaultPageSize when zero
+	PageSize int
+
+	// Prefetch is the number of pages fetched concurrently ahead of the page being read
+	Prefetch int
+
+	// Limit stops iteration after this many items; zero means every item
+	Limit int
+}
+
+// Pager iterates over every item of a paginated list endpoint.
+// Endpoints report their TotalRecordCount, so once the first page has been read
+// the following pages can be fetched ahead while earlier items are being consumed.
+type Pager[T any] struct {
+	fetch PageFunc[T]
+	opts  PageOptions
+	total int
+}
+
+// page is the outcome of fetching a single page
+type page[T any] struct {
+	items []T
+	total int
+	err   error
+}
+
+// NewPager creates a pager fetching pages with fetch
+func NewPager[T any](fetch PageFunc[T], opts PageOptions) *Pager[T] {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Prefetch < 0 {
+		opts.Prefetch = 0
+	}
+
+	return &Pager[T]{fetch: fetch, opts: opts}
+}
+
+// Total returns the total number of items reported by the most recently fetched page
+func (p *Pager[T]) Total() int {
+	return p.total
+}
+
+// All returns an iterator over the items in order, fetching pages as needed.
+// Iteration stops at the first error, which is yielded with a zero item.
+// Pages still being prefetched are cancelled when the loop ends early.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		pageSize := p.opts.PageSize
+		if p.opts.Limit > 0 {
+			pageSize = min(pageSize, p.opts.Limit)
+		}
+
+		start := p.opts.StartIndex
+		next := start + pageSize
+		queue := []<-chan page[T]{p.fetchAsync(ctx, start, pageSize)}
+		yielded := 0
+
+		for len(queue) > 0 {
+			current := <-queue[0]
+			queue = queue[1:]
+
+			if current.err != nil {
+				var zero T
+				yield(zero, current.err)
+				return
+			}
+			p.total = current.total
+			if len(current.items) == 0 {
+				return
+			}
+
+			end := current.total
+			if p.opts.Limit > 0 {
+				end = min(end, start+p.opts.Limit)
+			}
+
+			// Fetch ahead while this page is being consumed
+			for len(queue) < p.opts.Prefetch && next < end {
+				queue = append(queue, p.fetchAsync(ctx, next, min(pageSize, end-next)))
+				next += pageSize
+			}
+
+			for _, item := range current.items {
+				if p.opts.Limit > 0 && yielded >= p.opts.Limit {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				yielded++
+			}
+
+			// Without prefetching, the next page is only requested once this one is consumed
+			if len(queue) == 0 && next < end {
+				queue = append(queue, p.fetchAsync(ctx, next, min(pageSize, end-next)))
+				next += pageSize
+			}
+		}
+	}
+}
+
+// fetchAsync fetches a page in the background
+func (p *Pager[T]) fetchAsync(ctx context.Context, startIndex, limit int) <-chan page[T] {
+	result := make(chan page[T], 1)
+
+	go func() {
+		items, total, err := p.fetch(ctx, startIndex, limit)
+		result <- page[T]{items: items, total: total, err: err}
+	}()
+
+	return result
+}
+
+// slicePage returns the part of a complete list covered by a page
+func slicePage[T any](items []T, startIndex, limit int) []T {
+	startIndex = min(max(startIndex, 0), len(items))
+	end := min(startIndex+limit, len(items))
+
+	return items[startIndex:end]
+}
@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+// testServer is a fake Jellyfin server recording the requests it receives
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+// newTestServer starts a fake server answering every request with handler
+func newTestServer(t *testing.T, handler http.HandlerFunc) *testServer {
+	t.Helper()
+
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// received returns the requests received so far
+func (s *testServer) received() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// newTestClient creates a client for a fake server, without retries unless configured
+func newTestClient(t *testing.T, baseURL string, config models.JellyfinConfig, opts ...Option) *JellyfinClient {
+	t.Helper()
+
+	config.BaseURL = baseURL
+	c, err := NewClient(config, zap.NewNop().Sugar(), opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	return c.(*JellyfinClient)
+}
+
+// writeJSON answers a request with a JSON body
+func writeJSON(t *testing.T, w http.ResponseWriter, value any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+// testItemID returns the ID of the nth item of a fake library
+func testItemID(n int) string {
+	return fmt.Sprintf("%032x", n)
+}
+
+// itemsHandler serves a library of total items from the Items endpoint
+func itemsHandler(t *testing.T, total int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		result := models.QueryResult[models.Item]{Items: []models.Item{}, TotalRecordCount: total, StartIndex: start}
+		for i := start; i < min(start+limit, total); i++ {
+			result.Items = append(result.Items, models.Item{ID: testItemID(i)})
+		}
+		writeJSON(t, w, result)
+	}
+}
+
+// pageRequests returns the pages requested from the Items endpoint as "startIndex:limit",
+// in the order of their start index
+func pageRequests(requests []*http.Request) []string {
+	slices.SortFunc(requests, func(a, b *http.Request) int {
+		startA, _ := strconv.Atoi(a.URL.Query().Get("startIndex"))
+		startB, _ := strconv.Atoi(b.URL.Query().Get("startIndex"))
+		return startA - startB
+	})
+
+	pages := make([]string, 0, len(requests))
+	for _, r := range requests {
+		start, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		pages = append(pages, fmt.Sprintf("%d:%s", start, r.URL.Query().Get("limit")))
+	}
+
+	return pages
+}
+
+func TestPagerAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		opts      PageOptions
+		stopAfter int
+		wantFirst int
+		wantCount int
+		wantPages []string
+	}{
+		{
+			name:      "limit smaller than a page",
+			total:     25,
+			opts:      PageOptions{PageSize: 10, Prefetch: 2, Limit: 3},
+			wantCount: 3,
+			wantPages: []string{"0:3"},
+		},
+		{
+			name:      "limit crossing a page boundary",
+			total:     25,
+			opts:      PageOptions{PageSize: 10, Prefetch: 2, Limit: 15},
+			wantCount: 15,
+			wantPages: []string{"0:10", "10:5"},
+		},
+		{
+			name:      "short last page",
+			total:     25,
+			opts:      PageOptions{PageSize: 10, Prefetch: 2},
+			wantCount: 25,
+			wantPages: []string{"0:10", "10:10", "20:5"},
+		},
+		{
+			name:      "short last page without prefetching",
+			total:     25,
+			opts:      PageOptions{PageSize: 10},
+			wantCount: 25,
+			wantPages: []string{"0:10", "10:10", "20:5"},
+		},
+		{
+			name:      "start index",
+			total:     25,
+			opts:      PageOptions{StartIndex: 5, PageSize: 10, Prefetch: 1, Limit: 12},
+			wantFirst: 5,
+			wantCount: 12,
+			wantPages: []string{"5:10", "15:2"},
+		},
+		{
+			name:      "limit beyond the total",
+			total:     7,
+			opts:      PageOptions{PageSize: 5, Prefetch: 2, Limit: 50},
+			wantCount: 7,
+			wantPages: []string{"0:5", "5:2"},
+		},
+		{
+			name:      "empty list",
+			total:     0,
+			opts:      PageOptions{PageSize: 10, Prefetch: 2},
+			wantCount: 0,
+			wantPages: []string{"0:10"},
+		},
+		{
+			name:      "consumer breaking early",
+			total:     100,
+			opts:      PageOptions{PageSize: 10, Prefetch: 0},
+			stopAfter: 12,
+			wantCount: 12,
+			wantPages: []string{"0:10", "10:10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, itemsHandler(t, tt.total))
+			c := newTestClient(t, server.URL, models.JellyfinConfig{})
+
+			var ids []string
+			for item, err := range c.PageItems(ItemQuery{}, tt.opts).All(context.Background()) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ids = append(ids, item.ID)
+				if tt.stopAfter > 0 && len(ids) == tt.stopAfter {
+					break
+				}
+			}
+
+			if len(ids) != tt.wantCount {
+				t.Fatalf("got %d items, want %d", len(ids), tt.wantCount)
+			}
+			for i, id := range ids {
+				if want := testItemID(tt.wantFirst + i); id != want {
+					t.Fatalf("item %d is %s, want %s", i, id, want)
+				}
+			}
+
+			if got := pageRequests(server.received()); !slices.Equal(got, tt.wantPages) {
+				t.Errorf("requested pages %v, want %v", got, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestPagerAllBreakCancelsPrefetch(t *testing.T) {
+	// Pages after the first block until their fetch is cancelled
+	var cancelled sync.WaitGroup
+	cancelled.Add(2)
+	fetch := func(ctx context.Context, startIndex, limit int) ([]int, int, error) {
+		if startIndex > 0 {
+			<-ctx.Done()
+			cancelled.Done()
+			return nil, 0, ctx.Err()
+		}
+		return make([]int, limit), 100, nil
+	}
+
+	for _, err := range NewPager(fetch, PageOptions{PageSize: 10, Prefetch: 2}).All(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		break
+	}
+
+	// Returns once both prefetched pages were cancelled, or times out the test
+	cancelled.Wait()
+}
+
+func TestPagerAllError(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startIndex") == "10" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		itemsHandler(t, 30)(w, r)
+	})
+	c := newTestClient(t, server.URL, models.JellyfinConfig{})
+
+	count := 0
+	var lastErr error
+	for _, err := range c.PageItems(ItemQuery{}, PageOptions{PageSize: 10, Prefetch: 2}).All(context.Background()) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		count++
+	}
+
+	if count != 10 {
+		t.Errorf("got %d items before the error, want 10", count)
+	}
+	if lastErr == nil {
+		t.Fatal("expected the failed page's error")
+	}
+}
+
+func TestPageUsersFetchesOnce(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		users := make([]models.User, 5)
+		for i := range users {
+			users[i] = models.User{ID: testItemID(i)}
+		}
+		writeJSON(t, w, users)
+	})
+	c := newTestClient(t, server.URL, models.JellyfinConfig{})
+
+	var ids []string
+	for user, err := range c.PageUsers(UserQuery{}, PageOptions{PageSize: 2, Prefetch: 2}).All(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, user.ID)
+	}
+
+	if len(ids) != 5 {
+		t.Errorf("got %d users, want 5", len(ids))
+	}
+	if requests := len(server.received()); requests != 1 {
+		t.Errorf("users were listed %d times, want once", requests)
+	}
+}
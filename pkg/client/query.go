@@ -0,0 +1,294 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+// Query structs describe the parameters of list endpoints. Each field is encoded
+// under the name in its `query` tag, followed by any of these options:
+//
+//	omitempty    leave the parameter out when the field has its zero value
+//	required     reject the query when the field has its zero value
+//	nonnegative  reject negative numbers
+//	id           reject values that aren't Jellyfin IDs
+//	oneof=a|b    reject values other than those listed
+//
+// Slices are joined with commas and times are sent in UTC as RFC 3339.
+
+// SessionQuery filters the sessions returned by ListSessions
+type SessionQuery struct {
+	// ActiveWithinSeconds only returns sessions active within this many seconds
+	ActiveWithinSeconds int `query:"activeWithinSeconds,omitempty,nonnegative"`
+
+	// ControllableByUserID only returns sessions this user can remote control
+	ControllableByUserID string `query:"controllableByUserId,omitempty,id"`
+
+	// DeviceID only returns sessions of this device
+	DeviceID string `query:"deviceId,omitempty"`
+}
+
+// ActivityLogQuery filters the entries returned by ListActivityLogs
+type ActivityLogQuery struct {
+	StartIndex int `query:"startIndex,omitempty,nonnegative"`
+	Limit      int `query:"limit,omitempty,nonnegative"`
+
+	// MinDate only returns entries logged at or after this time
+	MinDate time.Time `query:"minDate,omitempty"`
+
+	// HasUserID only returns entries with (true) or without (false) a user
+	HasUserID *bool `query:"hasUserId,omitempty"`
+}
+
+// UserQuery filters the users returned by ListUsers
+type UserQuery struct {
+	IsHidden   *bool `query:"isHidden,omitempty"`
+	IsDisabled *bool `query:"isDisabled,omitempty"`
+}
+
+// ItemQuery filters the items returned by ListItems
+type ItemQuery struct {
+	// UserID queries items as seen by a user, defaulting to the configured user
+	UserID string `query:"userId,omitempty,id"`
+
+	// ParentID only returns items in a library or folder
+	ParentID string `query:"parentId,omitempty,id"`
+
+	// Recursive includes items in subfolders of the parent
+	Recursive bool `query:"recursive,omitempty"`
+
+	IncludeItemTypes []string `query:"includeItemTypes,omitempty"`
+	SearchTerm       string   `query:"searchTerm,omitempty"`
+	SortBy           []string `query:"sortBy,omitempty"`
+	SortOrder        string   `query:"sortOrder,omitempty,oneof=Ascending|Descending"`
+
+	// Fields requests optional fields such as Path or DateCreated
+	Fields []string `query:"fields,omitempty"`
+
+	StartIndex int `query:"startIndex,omitempty,nonnegative"`
+	Limit      int `query:"limit,omitempty,nonnegative"`
+}
+
+// SearchQuery describes a search for content
+type SearchQuery struct {
+	SearchTerm       string   `query:"searchTerm,required"`
+	IncludeItemTypes []string `query:"includeItemTypes,omitempty"`
+	UserID           string   `query:"userId,omitempty,id"`
+
+	StartIndex int `query:"startIndex,omitempty,nonnegative"`
+	Limit      int `query:"limit,omitempty,nonnegative"`
+}
+
+// TaskQuery filters the scheduled tasks returned by ListScheduledTasks
+type TaskQuery struct {
+	IsHidden  *bool `query:"isHidden,omitempty"`
+	IsEnabled *bool `query:"isEnabled,omitempty"`
+}
+
+// RefreshOptions control how RefreshItem refreshes an item's metadata and images.
+// The refresh modes are None, ValidationOnly, Default or FullRefresh; Default only
+// fills in what is missing, and the server's default is used when a mode is empty.
+type RefreshOptions struct {
+	MetadataRefreshMode string `query:"metadataRefreshMode,omitempty,oneof=None|ValidationOnly|Default|FullRefresh"`
+	ImageRefreshMode    string `query:"imageRefreshMode,omitempty,oneof=None|ValidationOnly|Default|FullRefresh"`
+
+	// ReplaceAllMetadata and ReplaceAllImages discard what the item has instead of keeping it
+	ReplaceAllMetadata bool `query:"replaceAllMetadata,omitempty"`
+	ReplaceAllImages   bool `query:"replaceAllImages,omitempty"`
+
+	// Recursive also refreshes the items within a folder, such as a series' episodes
+	Recursive bool `query:"recursive,omitempty"`
+}
+
+// PlaystateOptions are the arguments of a playback command
+type PlaystateOptions struct {
+	// SeekPositionTicks is the position to seek to, for the seek command
+	SeekPositionTicks int64 `query:"seekPositionTicks,omitempty,nonnegative"`
+}
+
+// PlayOptions are the arguments of a play request
+type PlayOptions struct {
+	// StartPositionTicks is the position to start playing the first item from
+	StartPositionTicks int64 `query:"startPositionTicks,omitempty,nonnegative"`
+}
+
+// playQuery is the query of a play request
+type playQuery struct {
+	PlayCommand models.PlayCommand `query:"playCommand,required,oneof=PlayNow|PlayNext|PlayLast"`
+	ItemIDs     []string           `query:"itemIds,required,id"`
+	PlayOptions
+}
+
+// libraryFolderQuery identifies a library folder, and how to change it
+type libraryFolderQuery struct {
+	Name           string `query:"name,required"`
+	CollectionType string `query:"collectionType,omitempty,oneof=movies|tvshows|music|musicvideos|homevideos|boxsets|books|mixed"`
+	NewName        string `query:"newName,omitempty"`
+	Path           string `query:"path,omitempty"`
+
+	// RefreshLibrary scans the libraries once the change is made
+	RefreshLibrary bool `query:"refreshLibrary,omitempty"`
+}
+
+// availableOptionsQuery asks for the providers a type of library can use
+type availableOptionsQuery struct {
+	LibraryContentType string `query:"libraryContentType,omitempty,oneof=movies|tvshows|music|musicvideos|homevideos|boxsets|books|mixed"`
+	IsNewLibrary       bool   `query:"isNewLibrary"`
+}
+
+// deviceQuery identifies a device
+type deviceQuery struct {
+	ID string `query:"id,required"`
+}
+
+// encodeQuery validates a query struct and encodes it as URL parameters.
+// A nil query encodes to no parameters.
+func encodeQuery(query any) (url.Values, error) {
+	values := url.Values{}
+	if query == nil {
+		return values, nil
+	}
+
+	v := reflect.ValueOf(query)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query must be a struct, not %s", v.Type())
+	}
+
+	if err := encodeFields(v, values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// encodeFields encodes the tagged fields of a struct, including those of embedded structs
+func encodeFields(v reflect.Value, values url.Values) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := encodeFields(v.Field(i), values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("query")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		value, isZero, err := formatQueryValue(v.Field(i))
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+
+		if err := checkQueryValue(v.Field(i), value, isZero, options); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+
+		if isZero && hasOption(options, "omitempty") {
+			continue
+		}
+		values.Set(name, value)
+	}
+
+	return nil
+}
+
+// formatQueryValue formats a field for a URL, reporting whether it has its zero value
+func formatQueryValue(v reflect.Value) (string, bool, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", true, nil
+		}
+		value, _, err := formatQueryValue(v.Elem())
+		return value, false, err
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return "", true, nil
+		}
+		return t.UTC().Format(time.RFC3339Nano), false, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.Len() == 0, nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), !v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), v.Int() == 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), v.Uint() == 0, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return "", false, fmt.Errorf("unsupported type %s", v.Type())
+		}
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = v.Index(i).String()
+		}
+		return strings.Join(parts, ","), v.Len() == 0, nil
+	default:
+		return "", false, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// checkQueryValue applies the validation options of a field
+func checkQueryValue(v reflect.Value, value string, isZero bool, options string) error {
+	if hasOption(options, "required") && isZero {
+		return fmt.Errorf("a value is required")
+	}
+	if isZero {
+		return nil
+	}
+
+	if hasOption(options, "nonnegative") && v.CanInt() && v.Int() < 0 {
+		return fmt.Errorf("%s must not be negative", value)
+	}
+
+	if hasOption(options, "id") {
+		ids := []string{value}
+		if v.Kind() == reflect.Slice {
+			ids = strings.Split(value, ",")
+		}
+		for _, id := range ids {
+			if !idPattern.MatchString(id) {
+				return fmt.Errorf("%q is not a valid ID", id)
+			}
+		}
+	}
+
+	for _, option := range strings.Split(options, ",") {
+		allowed, ok := strings.CutPrefix(option, "oneof=")
+		if !ok {
+			continue
+		}
+		if !slices.ContainsFunc(strings.Split(allowed, "|"), func(a string) bool { return strings.EqualFold(a, value) }) {
+			return fmt.Errorf("%q must be one of %s", value, strings.ReplaceAll(allowed, "|", ", "))
+		}
+	}
+
+	return nil
+}
+
+// hasOption reports whether a comma-separated tag option list contains an option
+func hasOption(options, option string) bool {
+	return slices.Contains(strings.Split(options, ","), option)
+}
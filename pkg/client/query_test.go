@@ -0,0 +1,142 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	yes, no := true, false
+	userID, parentID := testItemID(1), testItemID(2)
+
+	tests := []struct {
+		name  string
+		query any
+		want  string
+	}{
+		{name: "nil", query: nil, want: ""},
+		{name: "nil pointer", query: (*TaskQuery)(nil), want: ""},
+		{name: "empty", query: SessionQuery{}, want: ""},
+		{name: "pointer", query: &TaskQuery{IsHidden: &no}, want: "isHidden=false"},
+		{
+			name:  "sessions",
+			query: SessionQuery{ActiveWithinSeconds: 960, ControllableByUserID: userID, DeviceID: "living room"},
+			want:  "activeWithinSeconds=960&controllableByUserId=" + userID + "&deviceId=living+room",
+		},
+		{
+			name: "activity log",
+			query: ActivityLogQuery{
+				StartIndex: 20,
+				Limit:      10,
+				MinDate:    time.Date(2024, 1, 2, 3, 4, 5, 600_000_000, time.FixedZone("CEST", 2*60*60)),
+				HasUserID:  &no,
+			},
+			want: "hasUserId=false&limit=10&minDate=2024-01-02T01%3A04%3A05.6Z&startIndex=20",
+		},
+		{name: "users", query: UserQuery{IsHidden: &no, IsDisabled: &yes}, want: "isDisabled=true&isHidden=false"},
+		{
+			name: "items",
+			query: ItemQuery{
+				UserID:           userID,
+				ParentID:         parentID,
+				Recursive:        true,
+				IncludeItemTypes: []string{"Movie", "Series"},
+				SearchTerm:       "star wars",
+				SortBy:           []string{"SortName", "ProductionYear"},
+				SortOrder:        "Descending",
+				Fields:           []string{"Path"},
+				StartIndex:       100,
+				Limit:            50,
+			},
+			want: "fields=Path&includeItemTypes=Movie%2CSeries&limit=50&parentId=" + parentID +
+				"&recursive=true&searchTerm=star+wars&sortBy=SortName%2CProductionYear&sortOrder=Descending&startIndex=100&userId=" + userID,
+		},
+		{name: "items with empty slices", query: ItemQuery{IncludeItemTypes: []string{}, SortOrder: "descending"}, want: "sortOrder=descending"},
+		{name: "search", query: SearchQuery{SearchTerm: "alien", Limit: 5}, want: "limit=5&searchTerm=alien"},
+		{name: "tasks", query: TaskQuery{IsEnabled: &yes}, want: "isEnabled=true"},
+		{
+			name:  "refresh",
+			query: RefreshOptions{MetadataRefreshMode: "FullRefresh", ReplaceAllImages: true, Recursive: true},
+			want:  "metadataRefreshMode=FullRefresh&recursive=true&replaceAllImages=true",
+		},
+		{name: "playstate", query: PlaystateOptions{}, want: ""},
+		{
+			name:  "play with embedded options",
+			query: playQuery{PlayCommand: models.PlayNext, ItemIDs: []string{userID, parentID}, PlayOptions: PlayOptions{StartPositionTicks: 600_000_000}},
+			want:  "itemIds=" + userID + "%2C" + parentID + "&playCommand=PlayNext&startPositionTicks=600000000",
+		},
+		{
+			name:  "library folder",
+			query: libraryFolderQuery{Name: "Movies", CollectionType: "movies", Path: "/media/movies", RefreshLibrary: true},
+			want:  "collectionType=movies&name=Movies&path=%2Fmedia%2Fmovies&refreshLibrary=true",
+		},
+		{name: "zero value without omitempty", query: availableOptionsQuery{LibraryContentType: "tvshows"}, want: "isNewLibrary=false&libraryContentType=tvshows"},
+		{name: "device", query: deviceQuery{ID: "abc-123"}, want: "id=abc-123"},
+		{
+			name: "untagged and skipped fields",
+			query: struct {
+				Untagged string
+				Skipped  string `query:"-"`
+				Kept     string `query:"kept"`
+			}{Untagged: "a", Skipped: "b", Kept: "c"},
+			want: "kept=c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := encodeQuery(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := values.Encode(); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeQueryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   any
+		wantErr string
+	}{
+		{name: "not a struct", query: "limit=5", wantErr: "query must be a struct"},
+		{name: "required", query: SearchQuery{}, wantErr: "invalid searchTerm: a value is required"},
+		{name: "required slice", query: playQuery{PlayCommand: models.PlayNow}, wantErr: "invalid itemIds: a value is required"},
+		{name: "negative", query: ItemQuery{Limit: -1}, wantErr: "invalid limit: -1 must not be negative"},
+		{name: "invalid ID", query: ItemQuery{ParentID: "movies"}, wantErr: `invalid parentId: "movies" is not a valid ID`},
+		{name: "invalid ID in a list", query: playQuery{PlayCommand: models.PlayNow, ItemIDs: []string{testItemID(1), "x"}}, wantErr: `invalid itemIds: "x" is not a valid ID`},
+		{name: "not one of", query: ItemQuery{SortOrder: "Sideways"}, wantErr: `invalid sortOrder: "Sideways" must be one of Ascending, Descending`},
+		{
+			name: "unsupported slice",
+			query: struct {
+				IDs []int `query:"ids"`
+			}{},
+			wantErr: "invalid ids: unsupported type []int",
+		},
+		{
+			name: "unsupported kind",
+			query: struct {
+				Headers map[string]string `query:"headers"`
+			}{},
+			wantErr: "invalid headers: unsupported type map[string]string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encodeQuery(tt.query)
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %q, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
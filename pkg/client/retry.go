@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds each HTTP request when no timeout is configured
+	DefaultTimeout = 30 * time.Second
+
+	// retryBaseDelay and retryMaxDelay bound the exponential backoff between attempts
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// noRetriesKey marks contexts whose requests are attempted only once
+type noRetriesKey struct{}
+
+// WithoutRetries returns a context whose requests are attempted only once, whatever the
+// configured retries, for checks where a retry would hide a failure or inflate the latency
+func WithoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+// retriesFor returns how many times a request may be retried
+func retriesFor(ctx context.Context, method string, configured int) int {
+	if !isIdempotent(method) || ctx.Value(noRetriesKey{}) != nil {
+		return 0
+	}
+
+	return max(configured, 0)
+}
+
+// isIdempotent reports whether a request with this method can safely be sent again
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isReadOnly reports whether a request with this method leaves the server unchanged
+func isReadOnly(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether a failed attempt is worth repeating:
+// network errors and the statuses proxies return while the server restarts
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay returns how long to wait before the next attempt.
+// The server's Retry-After header is honored; otherwise the delay doubles with
+// each attempt, with jitter so that concurrent clients don't retry in lockstep.
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(delay, retryMaxDelay)
+		}
+	}
+
+	delay := min(retryBaseDelay<<attempt, retryMaxDelay)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		slack  time.Duration
+		wantOK bool
+	}{
+		{name: "missing", value: "", wantOK: false},
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-5", wantOK: false},
+		{name: "garbage", value: "soon", wantOK: false},
+		{name: "future date", value: time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat), want: 90 * time.Second, slack: 2 * time.Second, wantOK: true},
+		{name: "past date", value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), want: 0, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if got < tt.want-tt.slack || got > tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s (within %s)", tt.value, got, tt.want, tt.slack)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Run("backoff with jitter", func(t *testing.T) {
+		for attempt := range 10 {
+			full := min(retryBaseDelay<<attempt, retryMaxDelay)
+			for range 100 {
+				delay := retryDelay(attempt, nil)
+				if delay < full/2 || delay > full {
+					t.Fatalf("attempt %d waited %s, want between %s and %s", attempt, delay, full/2, full)
+				}
+			}
+		}
+	})
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "retry after", retryAfter: "5", want: 5 * time.Second},
+		{name: "retry after capped", retryAfter: "3600", want: retryMaxDelay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {tt.retryAfter}}}
+			if got := retryDelay(3, resp); got != tt.want {
+				t.Errorf("retryDelay = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("response without retry after", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+		if delay := retryDelay(0, resp); delay < retryBaseDelay/2 || delay > retryBaseDelay {
+			t.Errorf("retryDelay = %s, want between %s and %s", delay, retryBaseDelay/2, retryBaseDelay)
+		}
+	})
+}
+
+func TestShouldRetry(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "network error", ctx: context.Background(), err: errors.New("connection reset"), want: true},
+		{name: "cancelled", ctx: cancelled, err: context.Canceled, want: false},
+		{name: "too many requests", ctx: context.Background(), status: http.StatusTooManyRequests, want: true},
+		{name: "bad gateway", ctx: context.Background(), status: http.StatusBadGateway, want: true},
+		{name: "unavailable", ctx: context.Background(), status: http.StatusServiceUnavailable, want: true},
+		{name: "gateway timeout", ctx: context.Background(), status: http.StatusGatewayTimeout, want: true},
+		{name: "server error", ctx: context.Background(), status: http.StatusInternalServerError, want: false},
+		{name: "not found", ctx: context.Background(), status: http.StatusNotFound, want: false},
+		{name: "success", ctx: context.Background(), status: http.StatusOK, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+			if got := shouldRetry(tt.ctx, resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		withoutRetry bool
+		wantAttempts int
+	}{
+		{name: "GET is retried", method: http.MethodGet, wantAttempts: 3},
+		{name: "DELETE is retried", method: http.MethodDelete, wantAttempts: 3},
+		{name: "POST is not retried", method: http.MethodPost, wantAttempts: 1},
+		{name: "without retries", method: http.MethodGet, withoutRetry: true, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+			c := newTestClient(t, server.URL, models.JellyfinConfig{Retries: 2})
+
+			ctx := context.Background()
+			if tt.withoutRetry {
+				ctx = WithoutRetries(ctx)
+			}
+
+			var apiErr *APIError
+			if err := c.doRequest(ctx, tt.method, "System/Ping", nil, nil, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+				t.Fatalf("got error %v, want a 503 API error", err)
+			}
+			if attempts := len(server.received()); attempts != tt.wantAttempts {
+				t.Errorf("sent %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxTraceBody is the number of body bytes logged when tracing
+const maxTraceBody = 2048
+
+// sensitiveHeaderWords mark headers whose values are redacted in traces, dumps and dry runs
+var sensitiveHeaderWords = []string{"authorization", "token", "secret", "key", "cookie"}
+
+// tokenPattern matches the access token in a MediaBrowser authorization header
+var tokenPattern = regexp.MustCompile(`Token="[^"]*"`)
+
+// secretFieldPattern matches the password and access token fields of JSON bodies, such as
+// those sent and received when signing in
+var secretFieldPattern = regexp.MustCompile(`(?i)("(?:Pw|Password|AccessToken)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// Option configures optional client behavior
+type Option func(*JellyfinClient)
+
+// WithTrace logs the method, URL, headers, timing and bodies of every request
+func WithTrace() Option {
+	return func(c *JellyfinClient) {
+		c.trace = true
+	}
+}
+
+// WithHAR records every request and response to recorder
+func WithHAR(recorder *HARRecorder) Option {
+	return func(c *JellyfinClient) {
+		c.har = recorder
+	}
+}
+
+// WithDryRun writes requests that would change the server to w instead of sending them.
+// Read-only requests are still sent, so commands can look up what they act on.
+func WithDryRun(w io.Writer) Option {
+	return func(c *JellyfinClient) {
+		c.dryRun = w
+	}
+}
+
+// traceTransport logs and records the exchanges sent through another transport
+type traceTransport struct {
+	next  http.RoundTripper
+	trace bool
+	har   *HARRecorder
+	log   func(msg string, keysAndValues ...interface{})
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody := redactBody(requestBody(req))
+	if t.trace {
+		t.log("Sending request", "method", req.Method, "url", redactURL(req.URL), "headers", redactHeaders(req.Header), "body", truncateBody(reqBody))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		elapsed := time.Since(start)
+		if t.trace {
+			t.log("Request failed", "method", req.Method, "url", redactURL(req.URL), "duration", elapsed, "error", err)
+		}
+		if t.har != nil {
+			t.har.record(req, reqBody, nil, nil, start, elapsed, err)
+		}
+		return nil, err
+	}
+
+	// Buffer the body so it can be logged and still be read by the caller
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	elapsed := time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// The caller gets the body as received, only the logged and recorded copy is redacted
+	logBody := redactBody(respBody)
+	if t.trace {
+		t.log("Received response", "method", req.Method, "url", redactURL(req.URL), "status", resp.StatusCode, "duration", elapsed, "headers", redactHeaders(resp.Header), "body", truncateBody(logBody))
+	}
+	if t.har != nil {
+		t.har.record(req, reqBody, resp, logBody, start, elapsed, nil)
+	}
+
+	return resp, nil
+}
+
+// requestBody returns a copy of a request's body without consuming it
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	return data
+}
+
+// truncateBody formats a body for logging, cutting it short when it is long
+func truncateBody(body []byte) string {
+	if len(body) <= maxTraceBody {
+		return string(body)
+	}
+
+	return fmt.Sprintf("%s... (%d more bytes)", body[:maxTraceBody], len(body)-maxTraceBody)
+}
+
+// redactHeaders returns a copy of headers with credentials replaced
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for name, values := range redacted {
+		lower := strings.ToLower(name)
+		if !slices.ContainsFunc(sensitiveHeaderWords, func(word string) bool { return strings.Contains(lower, word) }) {
+			continue
+		}
+
+		// Only the token of a MediaBrowser header is secret, the rest identifies the client
+		for i, value := range values {
+			switch {
+			case tokenPattern.MatchString(value):
+				values[i] = tokenPattern.ReplaceAllString(value, `Token="REDACTED"`)
+			case !strings.HasPrefix(value, "MediaBrowser "):
+				values[i] = "REDACTED"
+			}
+		}
+	}
+
+	return redacted
+}
+
+// redactBody returns a copy of a body with passwords and access tokens replaced
+func redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	return secretFieldPattern.ReplaceAll(body, []byte(`${1}"REDACTED"`))
+}
+
+// printDryRun writes a request that won't be sent in the form of an HTTP message
+func printDryRun(w io.Writer, req *http.Request, body []byte) {
+	fmt.Fprintf(w, "%s %s\n", req.Method, redactURL(req.URL))
+
+	header := redactHeaders(req.Header)
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		for _, value := range header[name] {
+			fmt.Fprintf(w, "%s: %s\n", name, value)
+		}
+	}
+
+	if len(body) > 0 {
+		fmt.Fprintf(w, "\n%s\n", redactBody(body))
+	}
+	fmt.Fprintln(w)
+}
+
+// dryRunResponse is the response reported for a request that wasn't sent
+func dryRunResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+}
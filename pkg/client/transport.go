@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+// newTransport builds the HTTP transport for a server's connection settings
+func newTransport(config models.JellyfinConfig) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+
+	if config.Proxy != "" {
+		if config.UnixSocket != "" {
+			return nil, errors.New("proxy and unix_socket can't be used together")
+		}
+
+		proxyURL, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q: use http, https or socks5", proxyURL.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// Requests still carry the base URL's host, but are sent over the socket
+	if config.UnixSocket != "" {
+		dialer := &net.Dialer{}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", config.UnixSocket)
+		}
+	}
+
+	return transport, nil
+}
+
+// newTLSConfig builds the TLS settings for a server's certificate options
+func newTLSConfig(config models.JellyfinConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.SkipSSLVerify,
+	}
+
+	// Trust the bundle in addition to the system certificate authorities
+	if config.CACert != "" {
+		bundle, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", config.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCert != "" || config.ClientKey != "" {
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// addHeaders sets the configured extra headers on a request
+func (c *JellyfinClient) addHeaders(header http.Header) {
+	for name, value := range c.config.Headers {
+		header.Set(name, value)
+	}
+}
@@ -1,52 +1,259 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"strconv"
+	"io"
+	"iter"
+	"os"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
 	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
 )
 
+// serverActivityLogItem is an activity log entry tagged with the server it was found on
+type serverActivityLogItem struct {
+	Server string `json:"Server,omitempty"`
+	Change string `json:"Change,omitempty"`
+	models.ActivityLogItem
+}
+
+// serverActivityLog is an activity log merged from one or more servers
+type serverActivityLog struct {
+	Items      []serverActivityLogItem `json:"Items"`
+	TotalCount int                     `json:"TotalRecordCount"`
+	StartIndex int                     `json:"StartIndex"`
+
+	// Newest holds the date of the newest entry fetched from each server, matching or not
+	Newest map[string]time.Time `json:"-"`
+}
+
+// activityFetch is the outcome of fetching activity from a single server
+type activityFetch struct {
+	Log *models.ActivityLog
+
+	// Newest is the date of the newest entry fetched, whether or not it matched the filter
+	Newest time.Time
+}
+
+// activityFilter selects activity log entries. The start date and user presence are
+// filtered by the server; severities, types, the user and the end date are matched locally.
+type activityFilter struct {
+	StartIndex int
+	Limit      int
+	All        bool
+	MinDate    time.Time
+	MaxDate    time.Time
+	HasUserID  *bool
+	Severities []string
+	Types      []string
+	User       string
+}
+
+// local reports whether entries have to be matched after they are fetched
+func (f activityFilter) local() bool {
+	return len(f.Severities) > 0 || len(f.Types) > 0 || f.User != "" || !f.MaxDate.IsZero()
+}
+
+// query returns the part of the filter the server applies
+func (f activityFilter) query() client.ActivityLogQuery {
+	return client.ActivityLogQuery{MinDate: f.MinDate, HasUserID: f.HasUserID}
+}
+
+// matcher returns a function matching entries on the server behind c,
+// resolving the user filter by name on that server
+func (f activityFilter) matcher(ctx context.Context, c client.Client) (func(models.ActivityLogItem) bool, error) {
+	userID := ""
+	if f.User != "" {
+		users, err := c.ListUsers(ctx, client.UserQuery{})
+		if err != nil {
+			return nil, err
+		}
+
+		// Fall back to treating the value as an ID when no user has that name
+		userID = normalizeID(f.User)
+		for _, user := range users {
+			if strings.EqualFold(user.Name, f.User) {
+				userID = normalizeID(user.ID)
+				break
+			}
+		}
+	}
+
+	return func(item models.ActivityLogItem) bool {
+		if len(f.Severities) > 0 && !slices.ContainsFunc(f.Severities, func(severity string) bool {
+			return strings.HasPrefix(strings.ToLower(item.Severity), strings.ToLower(severity))
+		}) {
+			return false
+		}
+		if len(f.Types) > 0 && !slices.ContainsFunc(f.Types, func(t string) bool {
+			return strings.EqualFold(item.Type, t)
+		}) {
+			return false
+		}
+		if userID != "" && normalizeID(item.UserID) != userID {
+			return false
+		}
+		if !f.MaxDate.IsZero() && item.DateCreatedUTC.After(f.MaxDate) {
+			return false
+		}
+
+		return true
+	}, nil
+}
+
+// normalizeID lowercases an ID and strips dashes, so both GUID forms compare equal
+func normalizeID(id string) string {
+	return strings.ReplaceAll(strings.ToLower(id), "-", "")
+}
+
+// activityEntries returns the pager over a server's activity log and an iterator over
+// the entries in it that match a filter, newest first, up to the filter's limit.
+// When fetched isn't nil, it is called with every entry fetched, including those that don't match.
+func activityEntries(ctx context.Context, c client.Client, filter activityFilter, fetched func(models.ActivityLogItem)) (*client.Pager[models.ActivityLogItem], iter.Seq2[models.ActivityLogItem, error]) {
+	opts := client.PageOptions{StartIndex: filter.StartIndex, Prefetch: client.DefaultPrefetch}
+
+	// The server can stop at the limit when it does all the filtering
+	limited := !filter.All && filter.Limit > 0
+	if limited && !filter.local() {
+		opts.Limit = filter.Limit
+	}
+
+	pager := c.PageActivityLogs(filter.query(), opts)
+
+	return pager, func(yield func(models.ActivityLogItem, error) bool) {
+		match := func(models.ActivityLogItem) bool { return true }
+		if filter.local() {
+			matcher, err := filter.matcher(ctx, c)
+			if err != nil {
+				yield(models.ActivityLogItem{}, err)
+				return
+			}
+			match = matcher
+		}
+
+		matched := 0
+		for item, err := range pager.All(ctx) {
+			if err != nil {
+				yield(item, err)
+				return
+			}
+			if fetched != nil {
+				fetched(item)
+			}
+			if !match(item) {
+				continue
+			}
+			if !yield(item, nil) {
+				return
+			}
+
+			matched++
+			if limited && matched >= filter.Limit {
+				return
+			}
+		}
+	}
+}
+
+// fetchActivity collects the entries matching a filter from a single server
+func fetchActivity(ctx context.Context, c client.Client, filter activityFilter) (activityFetch, error) {
+	result := activityFetch{Log: &models.ActivityLog{Items: make([]models.ActivityLogItem, 0), StartIndex: filter.StartIndex}}
+
+	pager, entries := activityEntries(ctx, c, filter, func(item models.ActivityLogItem) {
+		if item.DateCreatedUTC.After(result.Newest) {
+			result.Newest = item.DateCreatedUTC
+		}
+	})
+	for item, err := range entries {
+		if err != nil {
+			return activityFetch{}, err
+		}
+		result.Log.Items = append(result.Log.Items, item)
+	}
+	result.Log.TotalCount = pager.Total()
+
+	return result, nil
+}
+
 // activityCmd represents the activity command
 var activityCmd = &cobra.Command{
 	Use:   "activity",
 	Short: "List activity logs from the Jellyfin server",
 	Long: `List recent activity logs from the Jellyfin server.
 	
-You can limit the number of results using the --limit flag.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get client
-		client := getClient()
+You can limit the number of results using the --limit flag, skip the newest
+entries with --start-index, or page through the whole log with --all.
+Use --watch to refresh the log until interrupted, highlighting new entries.
+Use --follow to print new entries as they are logged, like tail -f. It first
+prints the --limit newest entries, or every entry since --since or --min-date.
 
+Entries can be filtered by --severity, --type, --user and --has-user-id.
+Use --since with a duration (e.g. 2h) or a timestamp, or --min-date and
+--max-date with timestamps, to only show entries from a range of time.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get command flags
-		limit, _ := cmd.Flags().GetInt("limit")
-		outputJSON, _ := cmd.Flags().GetBool("json")
+		interval, _ := cmd.Flags().GetDuration("watch")
+		follow, _ := cmd.Flags().GetBool("follow")
+		followInterval, _ := cmd.Flags().GetDuration("interval")
 
-		// Set up parameters
-		params := make(map[string]string)
-		if limit > 0 {
-			params["limit"] = strconv.Itoa(limit)
+		if follow && interval > 0 {
+			return fmt.Errorf("--follow cannot be combined with --watch")
+		}
+
+		filter, err := activityFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		filter.Limit, _ = cmd.Flags().GetInt("limit")
+		filter.All, _ = cmd.Flags().GetBool("all")
+
+		// Print new entries as they appear in follow mode
+		if follow {
+			return followActivity(cmd.Context(), filter, followInterval)
+		}
+
+		// Keep refreshing in watch mode
+		if interval > 0 {
+			seen := make(map[string]bool)
+			first := true
+
+			return watch(cmd.Context(), cmd.CommandPath(), interval, func(ctx context.Context) (output.Result, error) {
+				logs, err := listActivity(ctx, filter)
+				if err != nil {
+					return output.Result{}, fmt.Errorf("failed to list activity logs: %w", err)
+				}
+
+				// Mark entries that appeared since the first refresh
+				for i, item := range logs.Items {
+					key := fmt.Sprintf("%s/%d", item.Server, item.ID)
+					if !first && !seen[key] {
+						logs.Items[i].Change = "new"
+					}
+					seen[key] = true
+				}
+				first = false
+
+				return activityResult(logs, true), nil
+			})
 		}
 
 		// Get activity logs
-		logs, err := client.ListActivityLogs(cmd.Context(), params)
+		logs, err := listActivity(cmd.Context(), filter)
 		if err != nil {
 			return fmt.Errorf("failed to list activity logs: %w", err)
 		}
 
 		// Output
-		if outputJSON {
-			outputActivityJSON(logs)
-		} else {
-			outputActivityText(logs)
-		}
-
-		return nil
+		return printResult(activityResult(logs, false))
 	},
 }
 
@@ -55,21 +262,270 @@ func init() {
 
 	// Add local flags
 	activityCmd.Flags().IntP("limit", "l", 10, "Limit the number of results")
+	activityCmd.Flags().Bool("all", false, "Page through the whole activity log, ignoring --limit")
+	activityCmd.Flags().BoolP("follow", "f", false, "Print new entries as they are logged until interrupted")
+	activityCmd.Flags().Duration("interval", defaultWatchInterval, "How often to check for new entries with --follow")
+	addActivityFilterFlags(activityCmd)
+	addWatchFlag(activityCmd)
+}
+
+// addActivityFilterFlags adds the flags that select activity log entries
+func addActivityFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("start-index", 0, "Skip this many of the newest entries")
+	cmd.Flags().String("since", "", "Only show entries logged since a duration ago (e.g. 2h) or a timestamp")
+	cmd.Flags().String("min-date", "", "Only show entries logged at or after a timestamp (e.g. 2024-05-01T12:00:00Z)")
+	cmd.Flags().String("max-date", "", "Only show entries logged at or before a timestamp")
+	cmd.Flags().StringSlice("severity", nil, "Only show entries with these severities (e.g. Error,Warning)")
+	cmd.Flags().StringSlice("type", nil, "Only show entries of these types (e.g. SessionStarted,AuthenticationFailed)")
+	cmd.Flags().String("user", "", "Only show entries for a user, by name or ID")
+	cmd.Flags().Bool("has-user-id", false, "Only show entries with a user (use --has-user-id=false for entries without)")
+}
+
+// activityFilterFromFlags builds an activity filter from the filter flags
+func activityFilterFromFlags(cmd *cobra.Command) (activityFilter, error) {
+	// Get command flags
+	startIndex, _ := cmd.Flags().GetInt("start-index")
+	since, _ := cmd.Flags().GetString("since")
+	minDate, _ := cmd.Flags().GetString("min-date")
+	maxDate, _ := cmd.Flags().GetString("max-date")
+	severities, _ := cmd.Flags().GetStringSlice("severity")
+	types, _ := cmd.Flags().GetStringSlice("type")
+	user, _ := cmd.Flags().GetString("user")
+
+	if startIndex < 0 {
+		return activityFilter{}, fmt.Errorf("--start-index must not be negative")
+	}
+	if since != "" && minDate != "" {
+		return activityFilter{}, fmt.Errorf("--since cannot be combined with --min-date")
+	}
+
+	filter := activityFilter{
+		StartIndex: startIndex,
+		Severities: severities,
+		Types:      types,
+		User:       user,
+	}
+
+	var err error
+	if since != "" {
+		if filter.MinDate, err = parseSince(since); err != nil {
+			return activityFilter{}, err
+		}
+	}
+	if minDate != "" {
+		if filter.MinDate, err = parseTimestamp(minDate); err != nil {
+			return activityFilter{}, err
+		}
+	}
+	if maxDate != "" {
+		if filter.MaxDate, err = parseTimestamp(maxDate); err != nil {
+			return activityFilter{}, err
+		}
+	}
+	if !filter.MinDate.IsZero() && !filter.MaxDate.IsZero() && filter.MaxDate.Before(filter.MinDate) {
+		return activityFilter{}, fmt.Errorf("--max-date must not be before the start date")
+	}
+
+	if cmd.Flags().Changed("has-user-id") {
+		hasUserID, _ := cmd.Flags().GetBool("has-user-id")
+		filter.HasUserID = &hasUserID
+	}
+
+	return filter, nil
+}
+
+// followActivity prints activity log entries oldest first as they are logged, until the context is cancelled.
+// The newest entry ID printed from each server is tracked so entries are never printed twice, and
+// each poll only asks for entries since the newest one fetched, whether or not it was printed.
+func followActivity(ctx context.Context, filter activityFilter, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("follow interval must be positive")
+	}
+
+	printer, err := newPrinter(os.Stdout)
+	if err != nil {
+		return err
+	}
+	switch printer.Format() {
+	case output.FormatText, output.FormatTable, output.FormatNDJSON:
+	default:
+		return fmt.Errorf("--follow can only be written as text or ndjson, not %s", printer.Format())
+	}
+
+	// Print everything since a given start date, the limit only applies to the tail shown first
+	if !filter.MinDate.IsZero() {
+		filter.Limit = 0
+	}
+
+	lastIDs := make(map[string]int64)
+	newest := make(map[string]time.Time)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		logs, err := listActivity(ctx, filter)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			logger.Errorw("Refresh failed", "error", fmt.Errorf("failed to list activity logs: %w", err))
+		} else {
+			// Entries are listed newest first
+			for i := len(logs.Items) - 1; i >= 0; i-- {
+				item := logs.Items[i]
+				if last, ok := lastIDs[item.Server]; ok && item.ID <= last {
+					continue
+				}
+				lastIDs[item.Server] = item.ID
+
+				if err := printer.Print(output.Result{
+					Data: item,
+					Text: func(w io.Writer) {
+						outputActivityEntryText(w, item)
+					},
+				}); err != nil {
+					return err
+				}
+			}
+
+			for server, date := range logs.Newest {
+				if date.After(newest[server]) {
+					newest[server] = date
+				}
+			}
+
+			// From now on only ask for entries since the newest one fetched from every server,
+			// without a limit so bursts between polls aren't cut short
+			if len(newest) > 0 {
+				var since time.Time
+				for _, date := range newest {
+					if since.IsZero() || date.Before(since) {
+						since = date
+					}
+				}
+				filter.MinDate = since
+			}
+			filter.StartIndex, filter.Limit, filter.All = 0, 0, false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// timestampLayouts are the timestamp formats accepted on the command line
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTimestamp parses a timestamp, in local time unless it has an offset
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q: use e.g. 2024-05-01T12:00:00Z or 2024-05-01", value)
+}
+
+// parseSince parses a point in time given as a duration before now (e.g. 2h) or a timestamp
+func parseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid duration %q: must not be negative", value)
+		}
+		return time.Now().Add(-d), nil
+	}
+
+	t, err := parseTimestamp(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value %q: use a duration (e.g. 2h) or a timestamp", value)
+	}
+
+	return t, nil
+}
+
+// listActivity fetches the activity log entries matching a filter from the target servers, merged newest first
+func listActivity(ctx context.Context, filter activityFilter) (*serverActivityLog, error) {
+	results, err := fanOut(ctx, func(ctx context.Context, c client.Client) (activityFetch, error) {
+		return fetchActivity(ctx, c, filter)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs := &serverActivityLog{Items: make([]serverActivityLogItem, 0), Newest: make(map[string]time.Time)}
+	for _, result := range results {
+		if !result.Value.Newest.IsZero() {
+			logs.Newest[result.Server] = result.Value.Newest
+		}
+		logs.TotalCount += result.Value.Log.TotalCount
+		logs.StartIndex = result.Value.Log.StartIndex
+		for _, item := range result.Value.Log.Items {
+			logs.Items = append(logs.Items, serverActivityLogItem{Server: result.Server, ActivityLogItem: item})
+		}
+	}
+
+	// Interleave entries from multiple servers, newest first
+	if len(results) > 1 {
+		sort.SliceStable(logs.Items, func(i, j int) bool {
+			return logs.Items[i].DateCreatedUTC.After(logs.Items[j].DateCreatedUTC)
+		})
+	}
+
+	return logs, nil
+}
+
+// activityResult describes activity logs for output, with change markers when watching
+func activityResult(logs *serverActivityLog, watching bool) output.Result {
+	columns := withServerColumn("Date", "Severity", "Name", "ShortOverview")
+	if watching {
+		columns = append([]string{"Change"}, columns...)
+	}
+
+	return output.Result{
+		Data:    logs,
+		Rows:    logs.Items,
+		Columns: columns,
+		Styles: map[string]output.StyleFunc{
+			"Severity": output.SeverityStyle,
+			"Change":   output.ChangeStyle,
+		},
+		Text: func(w io.Writer) {
+			outputActivityText(w, logs)
+		},
+	}
 }
 
 // outputActivityText outputs activity logs in human-readable format
-func outputActivityText(logs *models.ActivityLog) {
+func outputActivityText(w io.Writer, logs *serverActivityLog) {
 	if logs == nil || len(logs.Items) == 0 {
-		fmt.Println("No activity logs found")
+		fmt.Fprintln(w, "No activity logs found")
 		return
 	}
 
-	fmt.Printf("Activity Logs (Total: %d):\n", logs.TotalCount)
+	fmt.Fprintf(w, "Activity Logs (Total: %d):\n", logs.TotalCount)
 
 	for i, item := range logs.Items {
 		timeAgo := humanize.RelTime(time.Now(), item.DateCreatedUTC, "", "ago")
-		fmt.Printf(" %d. [%s] %s - %s (%s)\n",
+		marker := ""
+		if item.Change != "" {
+			marker = "* "
+		}
+
+		fmt.Fprintf(w, " %d. %s%s[%s] %s - %s (%s)\n",
 			i+1,
+			marker,
+			serverTag(item.Server),
 			item.Severity,
 			item.Name,
 			item.ShortOverview,
@@ -77,18 +533,17 @@ func outputActivityText(logs *models.ActivityLog) {
 
 		// Print the full overview if it's different from the short overview
 		if item.Overview != "" && item.Overview != item.ShortOverview {
-			fmt.Printf("    %s\n", item.Overview)
+			fmt.Fprintf(w, "    %s\n", item.Overview)
 		}
 	}
 }
 
-// outputActivityJSON outputs activity logs in JSON format
-func outputActivityJSON(logs *models.ActivityLog) {
-	jsonBytes, err := json.MarshalIndent(logs, "", "  ")
-	if err != nil {
-		logger.Errorw("Failed to marshal activity logs to JSON", "error", err)
-		return
-	}
-
-	fmt.Println(string(jsonBytes))
+// outputActivityEntryText writes a single activity log entry as a timestamped line
+func outputActivityEntryText(w io.Writer, item serverActivityLogItem) {
+	fmt.Fprintf(w, "%s %s[%s] %s - %s\n",
+		item.DateCreatedUTC.Local().Format(time.DateTime),
+		serverTag(item.Server),
+		item.Severity,
+		item.Name,
+		item.ShortOverview)
 }
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// activityExportColumns are the fields written by a CSV export
+var activityExportColumns = []string{"Id", "Date", "Severity", "Type", "Name", "ShortOverview", "Overview", "UserId", "ItemId"}
+
+// activityExportCmd represents the activity export command
+var activityExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the activity log to a file",
+	Long: `Export every activity log entry matching the filters as CSV or NDJSON.
+
+Entries are written page by page as they are fetched, so even a long history
+is never held in memory. Without --out the export is written to stdout.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		format, _ := cmd.Flags().GetString("format")
+		out, _ := cmd.Flags().GetString("out")
+
+		format = strings.ToLower(format)
+		if format != "csv" && format != "ndjson" {
+			return fmt.Errorf("invalid export format %q: use csv or ndjson", format)
+		}
+
+		filter, err := activityFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		filter.All = true
+
+		names, err := targetServers()
+		if err != nil {
+			return err
+		}
+
+		// Open the destination
+		var w io.Writer = os.Stdout
+		summary := os.Stderr
+		var file *os.File
+		if out != "" && out != "-" {
+			if file, err = os.Create(out); err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			defer file.Close()
+			w, summary = file, os.Stdout
+		}
+
+		exporter, err := newActivityExporter(w, format)
+		if err != nil {
+			return err
+		}
+
+		// Export each server in turn, writing entries as pages are fetched
+		failed := 0
+		for _, name := range names {
+			server, _ := lookupServer(name)
+			tag := ""
+			if allServers {
+				tag = name
+			}
+
+			c, err := serverClient(name, server)
+			if err == nil {
+				err = exporter.export(cmd.Context(), c, filter, tag)
+			}
+			if err != nil {
+				if !allServers {
+					return fmt.Errorf("failed to export activity logs: %w", err)
+				}
+				logger.Errorw("Export failed", "server", name, "error", err)
+				failed++
+			}
+		}
+		if failed > 0 && failed == len(names) {
+			return fmt.Errorf("export failed on all %d servers", failed)
+		}
+
+		if file != nil {
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to close export file: %w", err)
+			}
+		}
+
+		destination := out
+		if file == nil {
+			destination = "stdout"
+		}
+		fmt.Fprintf(summary, "Exported %d activity log entries to %s\n", exporter.count, destination)
+
+		return nil
+	},
+}
+
+func init() {
+	activityCmd.AddCommand(activityExportCmd)
+
+	// Add local flags
+	activityExportCmd.Flags().String("format", "csv", "Export format: csv or ndjson")
+	activityExportCmd.Flags().String("out", "", "File to write the export to (default is stdout)")
+	addActivityFilterFlags(activityExportCmd)
+}
+
+// activityExporter writes activity log entries in an export format
+type activityExporter struct {
+	buffered *bufio.Writer
+	write    func(serverActivityLogItem) error
+	count    int
+
+	// encoded completes the entries written so far, before the buffer is flushed
+	encoded func() error
+}
+
+// newActivityExporter creates an exporter writing to w in a format
+func newActivityExporter(w io.Writer, format string) (*activityExporter, error) {
+	e := &activityExporter{buffered: bufio.NewWriter(w)}
+
+	switch format {
+	case "ndjson":
+		encoder := json.NewEncoder(e.buffered)
+		e.write = func(item serverActivityLogItem) error {
+			return encoder.Encode(item)
+		}
+		e.encoded = func() error { return nil }
+
+	case "csv":
+		columns := withServerColumn(activityExportColumns...)
+
+		writer := csv.NewWriter(e.buffered)
+		if err := writer.Write(columns); err != nil {
+			return nil, fmt.Errorf("failed to write CSV header: %w", err)
+		}
+
+		e.write = func(item serverActivityLogItem) error {
+			record := make([]string, len(columns))
+			for i, column := range columns {
+				record[i] = output.FieldString(reflect.ValueOf(item), column)
+			}
+			return writer.Write(record)
+		}
+		e.encoded = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid export format %q: use csv or ndjson", format)
+	}
+
+	return e, nil
+}
+
+// export writes the entries matching a filter on one server,
+// flushing them to the destination after every page
+func (e *activityExporter) export(ctx context.Context, c client.Client, filter activityFilter, server string) error {
+	_, entries := activityEntries(ctx, c, filter, nil)
+
+	pending := 0
+	for item, err := range entries {
+		if err != nil {
+			return err
+		}
+		if err := e.write(serverActivityLogItem{Server: server, ActivityLogItem: item}); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+		e.count++
+
+		if pending++; pending >= client.DefaultPageSize {
+			if err := e.flush(); err != nil {
+				return err
+			}
+			pending = 0
+		}
+	}
+
+	return e.flush()
+}
+
+// flush writes buffered entries to the destination
+func (e *activityExporter) flush() error {
+	if err := e.encoded(); err != nil {
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+	if err := e.buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// configSettings is the YAML document of a config file.
+// Changes patch the document in place, so keys keep their case and untouched settings and comments are kept as written.
+type configSettings struct {
+	root *yaml.Node
+}
+
+// parseConfigSettings parses the contents of a config file, which may be empty
+func parseConfigSettings(data []byte) (configSettings, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return configSettings{}, err
+	}
+
+	if len(doc.Content) == 0 {
+		return configSettings{root: &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}}, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return configSettings{}, errors.New("config file is not a mapping of settings")
+	}
+
+	return configSettings{root: doc.Content[0]}, nil
+}
+
+// marshal returns the contents of the config file in the format of its extension
+func (s configSettings) marshal(ext string) ([]byte, error) {
+	switch strings.ToLower(ext) {
+	case "", ".yaml", ".yml":
+	case ".json":
+		// JSON files are read as YAML too, so only the output differs
+		var value any
+		if err := s.root.Decode(&value); err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, fmt.Errorf("only YAML and JSON config files can be updated, not %s", ext)
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(s.root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// lookup returns the value node of a key in a mapping, matched case-insensitively like viper does
+func lookup(mapping *yaml.Node, key string) (int, *yaml.Node) {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return -1, nil
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return i, mapping.Content[i+1]
+		}
+	}
+
+	return -1, nil
+}
+
+// Get returns the value at a dotted key path, or nil if it is not set
+func (s configSettings) Get(key string) any {
+	mapping, name := s.section(key, false)
+
+	_, node := lookup(mapping, name)
+	if node == nil {
+		return nil
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil
+	}
+
+	return value
+}
+
+// Set stores a value at a dotted key path, creating intermediate sections as needed
+func (s configSettings) Set(key string, value any) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		logger.Warnw("Failed to encode config setting", "key", key, "error", err)
+		return
+	}
+
+	mapping, name := s.section(key, true)
+	setNode(mapping, name, &node)
+}
+
+// section returns the mapping holding the last part of a dotted key path and that part,
+// creating the intermediate sections if create is set, or a nil mapping if they are missing
+func (s configSettings) section(key string, create bool) (*yaml.Node, string) {
+	parts := strings.Split(key, ".")
+
+	mapping := s.root
+	for _, part := range parts[:len(parts)-1] {
+		_, next := lookup(mapping, part)
+		if next == nil || next.Kind != yaml.MappingNode {
+			if !create {
+				return nil, ""
+			}
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setNode(mapping, part, next)
+		}
+		mapping = next
+	}
+
+	return mapping, parts[len(parts)-1]
+}
+
+// setNode replaces the value of a key in a mapping, keeping the key as written, or appends it
+func setNode(mapping *yaml.Node, key string, value *yaml.Node) {
+	if i, old := lookup(mapping, key); old != nil {
+		// Keep comments attached to the old value
+		value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+		mapping.Content[i+1] = value
+		return
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// Delete removes the value or section at a dotted key path
+func (s configSettings) Delete(key string) {
+	s.remove(key)
+}
+
+// Move moves the value or section at a dotted key path to another, keeping it as written
+func (s configSettings) Move(from, to string) {
+	if node := s.remove(from); node != nil {
+		mapping, name := s.section(to, true)
+		setNode(mapping, name, node)
+	}
+}
+
+// remove removes the value at a dotted key path and returns it, or nil if it is not set
+func (s configSettings) remove(key string) *yaml.Node {
+	mapping, name := s.section(key, false)
+
+	i, node := lookup(mapping, name)
+	if node == nil {
+		return nil
+	}
+	mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+	return node
+}
+
+// configFilePath returns the config file in use, or the default location for a new one
+func configFilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".config", "jellyfin-cli", "config.yaml"), nil
+}
+
+// updateConfig applies changes to the config file on disk.
+// Only the changed keys are written; defaults and flags are left out.
+func updateConfig(apply func(settings configSettings) error) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	settings, err := parseConfigSettings(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := apply(settings); err != nil {
+		return err
+	}
+
+	data, err = settings.marshal(filepath.Ext(path))
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	// The file may hold access tokens, so keep it private
+	if err := os.Chmod(path, 0o600); err != nil {
+		logger.Warnw("Failed to restrict config file permissions", "file", path, "error", err)
+	}
+
+	logger.Debugw("Updated config file", "file", path)
+	return nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const testConfig = `# Jellyfin servers
+default_server: home
+servers:
+  home:
+    base_url: http://jellyfin:8096
+    Token: old-token # from the dashboard
+    headers:
+      CF-Access-Client-Id: client-id
+      CF-Access-Client-Secret: client-secret
+logging:
+  level: info
+`
+
+func TestConfigSettingsSet(t *testing.T) {
+	settings, err := parseConfigSettings([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("parseConfigSettings: %v", err)
+	}
+
+	settings.Set("servers.home.token", "new-token")
+	settings.Set("servers.home.user_id", "u1")
+	settings.Set("servers.office.base_url", "http://office:8096")
+
+	data, err := settings.marshal(".yaml")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	want := `# Jellyfin servers
+default_server: home
+servers:
+  home:
+    base_url: http://jellyfin:8096
+    Token: new-token # from the dashboard
+    headers:
+      CF-Access-Client-Id: client-id
+      CF-Access-Client-Secret: client-secret
+    user_id: u1
+  office:
+    base_url: http://office:8096
+logging:
+  level: info
+`
+	if string(data) != want {
+		t.Errorf("got\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestConfigSettingsGetAndDelete(t *testing.T) {
+	settings, err := parseConfigSettings([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("parseConfigSettings: %v", err)
+	}
+
+	if got := settings.Get("default_server"); got != "home" {
+		t.Errorf("default_server = %v, want home", got)
+	}
+	if got := settings.Get("servers.home.token"); got != "old-token" {
+		t.Errorf("token = %v, want old-token", got)
+	}
+	if got := settings.Get("servers.office"); got != nil {
+		t.Errorf("servers.office = %v, want nil", got)
+	}
+
+	// Moving a section keeps the case of the keys inside it
+	settings.Move("servers.home", "servers.moved")
+	settings.Delete("servers.missing.token")
+
+	data, err := settings.marshal(".yaml")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), "home:") {
+		t.Errorf("servers.home was not deleted:\n%s", data)
+	}
+	if !strings.Contains(string(data), "  moved:\n    base_url: http://jellyfin:8096\n    Token: old-token # from the dashboard\n") {
+		t.Errorf("servers.home was not moved as written:\n%s", data)
+	}
+	if !strings.Contains(string(data), "CF-Access-Client-Secret: client-secret") {
+		t.Errorf("the moved headers lost their case:\n%s", data)
+	}
+}
+
+func TestConfigSettingsFormats(t *testing.T) {
+	empty, err := parseConfigSettings(nil)
+	if err != nil {
+		t.Fatalf("parseConfigSettings: %v", err)
+	}
+	empty.Set("api.token", "")
+	if data, err := empty.marshal(".yml"); err != nil || string(data) != "api:\n  token: \"\"\n" {
+		t.Errorf("new file = %q, %v", data, err)
+	}
+
+	settings, err := parseConfigSettings([]byte(`{"api": {"base_url": "http://jellyfin", "Headers": {"X-Custom": "1"}}}`))
+	if err != nil {
+		t.Fatalf("parseConfigSettings: %v", err)
+	}
+	settings.Set("api.token", "t")
+	data, err := settings.marshal(".json")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{
+  "api": {
+    "Headers": {
+      "X-Custom": "1"
+    },
+    "base_url": "http://jellyfin",
+    "token": "t"
+  }
+}
+`
+	if string(data) != want {
+		t.Errorf("got\n%s\nwant\n%s", data, want)
+	}
+
+	if _, err := settings.marshal(".toml"); err == nil {
+		t.Error("marshal to TOML succeeded, want an error")
+	}
+	if _, err := parseConfigSettings([]byte("- a list")); err == nil {
+		t.Error("parsing a list succeeded, want an error")
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// errNotConfirmed is returned when a destructive change is declined at the prompt
+var errNotConfirmed = errors.New("cancelled, nothing was changed")
+
+// addYesFlag adds the --yes flag, which skips the confirmation of destructive changes
+func addYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+}
+
+// confirm asks whether to go ahead with a destructive change, described as in "delete library Movies".
+// Nothing is asked with --yes or during a dry run, and without a terminal to ask on the change is refused.
+func confirm(cmd *cobra.Command, format string, args ...any) error {
+	// Get command flags
+	yes, _ := cmd.Flags().GetBool("yes")
+	if yes || dryRun {
+		return nil
+	}
+
+	action := fmt.Sprintf(format, args...)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("refusing to %s without --yes, as there is no terminal to confirm on", action)
+	}
+
+	answer, err := promptLine(bufio.NewReader(os.Stdin), fmt.Sprintf("Are you sure you want to %s? [y/N] ", action))
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		return errNotConfirmed
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+)
+
+var (
+	traceRequests bool
+	harFile       string
+	dryRun        bool
+
+	// harRecorder collects the requests of every client when --har is given
+	harRecorder *client.HARRecorder
+)
+
+// addDryRunFlag adds the --dry-run flag to a command that changes the server
+func addDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the requests that would change the server instead of sending them")
+}
+
+// startDebugging applies the --trace and --har flags before a command runs
+func startDebugging() {
+	if traceRequests && globalAtomicLevel != (zap.AtomicLevel{}) {
+		globalAtomicLevel.SetLevel(zap.DebugLevel)
+	}
+	if harFile != "" {
+		harRecorder = client.NewHARRecorder()
+	}
+}
+
+// finishDebugging writes the requests recorded for --har
+func finishDebugging() {
+	if harRecorder == nil {
+		return
+	}
+
+	if err := harRecorder.WriteFile(harFile); err != nil {
+		logger.Errorw("Failed to save HTTP archive", "file", harFile, "error", err)
+		return
+	}
+	logger.Debugw("Saved HTTP archive", "file", harFile)
+}
+
+// reportf prints the confirmation of a change, unless it was only a dry run
+func reportf(format string, a ...any) {
+	if dryRun {
+		return
+	}
+
+	fmt.Printf(format, a...)
+}
+
+// clientOptions returns the client options for the debugging flags
+func clientOptions() []client.Option {
+	var opts []client.Option
+	if traceRequests {
+		opts = append(opts, client.WithTrace())
+	}
+	if harRecorder != nil {
+		opts = append(opts, client.WithHAR(harRecorder))
+	}
+	if dryRun {
+		opts = append(opts, client.WithDryRun(os.Stdout))
+	}
+
+	return opts
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+)
+
+// Exit codes returned by the CLI, so scripts can tell failures apart
+const (
+	exitError        = 1
+	exitUnauthorized = 3
+	exitForbidden    = 4
+	exitNotFound     = 5
+	exitServerError  = 6
+	exitTaskFailed   = 7
+)
+
+// exitCode returns the process exit code for an error
+func exitCode(err error) int {
+	switch {
+	case client.IsUnauthorized(err):
+		return exitUnauthorized
+	case client.IsForbidden(err):
+		return exitForbidden
+	case client.IsNotFound(err):
+		return exitNotFound
+	case client.IsServerError(err):
+		return exitServerError
+	case errors.Is(err, errTaskFailed):
+		return exitTaskFailed
+	default:
+		return exitError
+	}
+}
+
+// errorHint returns a suggestion for resolving an error, if there is one
+func errorHint(err error) string {
+	switch {
+	case client.IsUnauthorized(err):
+		return "The server rejected the access token. Check the token in your config file or run 'jellyfin-cli login'."
+	case client.IsForbidden(err):
+		return "The token's user isn't allowed to do this. Some commands need an administrator."
+	case client.IsNotFound(err):
+		return "The server couldn't find what was requested. Check the name or ID."
+	case client.IsServerError(err):
+		return "The Jellyfin server failed to handle the request. Check the server logs for details."
+	case errors.Is(err, errTaskFailed):
+		return "Check the server logs, or 'jellyfin-cli activity' for the task's entries."
+	default:
+		return ""
+	}
+}
+
+// printError prints an error, along with a hint for resolving it if there is one
+func printError(w io.Writer, err error) {
+	fmt.Fprintf(w, "Error: %v\n", err)
+
+	if hint := errorHint(err); hint != "" {
+		fmt.Fprintln(w, hint)
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// serverEvent is an event tagged with the server it was received from
+type serverEvent struct {
+	Server string           `json:"Server,omitempty"`
+	Time   time.Time        `json:"Time"`
+	Type   models.EventType `json:"Type"`
+	Data   any              `json:"Data,omitempty"`
+}
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream live events from the Jellyfin server",
+	Long: `Stream live events from the Jellyfin server until interrupted.
+
+Events are received over a WebSocket connection, so short-lived changes that
+polling would miss are shown as they happen, for example:
+  Sessions, LibraryChanged, UserDataChanged, ScheduledTaskEnded, ActivityLogEntry
+
+Use --type to only show some event types. Events are written as text lines,
+or as one JSON document per line with --output ndjson.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		types, _ := cmd.Flags().GetStringSlice("type")
+
+		printer, err := newPrinter(os.Stdout)
+		if err != nil {
+			return err
+		}
+		switch printer.Format() {
+		case output.FormatText, output.FormatTable, output.FormatNDJSON:
+		default:
+			return fmt.Errorf("events can only be written as text or ndjson, not %s", printer.Format())
+		}
+
+		// Only subscribe to the feeds that will be shown
+		wanted := func(eventType models.EventType) bool {
+			return len(types) == 0 || slices.ContainsFunc(types, func(t string) bool {
+				return strings.EqualFold(t, string(eventType))
+			})
+		}
+		var subscriptions []models.EventType
+		for _, subscription := range models.EventSubscriptions {
+			if wanted(subscription) {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+
+		names, err := targetServers()
+		if err != nil {
+			return err
+		}
+
+		// Events from several servers arrive concurrently, so print one at a time
+		var mu sync.Mutex
+		handle := func(server string, event models.Event) error {
+			if !wanted(event.MessageType) {
+				return nil
+			}
+
+			data, err := event.Payload()
+			if err != nil {
+				logger.Warnw("Failed to decode event", "type", event.MessageType, "error", err)
+				data = event.Data
+			}
+
+			ev := serverEvent{Server: server, Time: time.Now(), Type: event.MessageType, Data: data}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			return printer.Print(output.Result{
+				Data: ev,
+				Text: func(w io.Writer) {
+					outputEventText(w, ev)
+				},
+			})
+		}
+
+		errs := make([]error, len(names))
+
+		var wg sync.WaitGroup
+		for i, name := range names {
+			server, ok := lookupServer(name)
+			if !ok {
+				return fmt.Errorf("server %q is not configured", name)
+			}
+			tag := ""
+			if allServers {
+				tag = name
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				c, err := serverClient(name, server)
+				if err == nil {
+					err = c.StreamEvents(cmd.Context(), subscriptions, func(event models.Event) error {
+						return handle(tag, event)
+					})
+				}
+				errs[i] = err
+				if errs[i] != nil && allServers {
+					logger.Errorw("Event stream failed", "server", name, "error", errs[i])
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Keep streaming while any server is still connected
+		failed := 0
+		for _, err := range errs {
+			if err != nil {
+				failed++
+			}
+		}
+		if failed == len(names) {
+			if len(names) == 1 {
+				return fmt.Errorf("failed to stream events: %w", errs[0])
+			}
+			return fmt.Errorf("failed to stream events: %w", errors.Join(errs...))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	// Add local flags
+	eventsCmd.Flags().StringSliceP("type", "t", nil, "Only show events of these types (comma-separated)")
+}
+
+// outputEventText writes an event as a line of text
+func outputEventText(w io.Writer, ev serverEvent) {
+	prefix := fmt.Sprintf("%s %s%s", ev.Time.Format(time.TimeOnly), serverTag(ev.Server), ev.Type)
+
+	switch data := ev.Data.(type) {
+	case []models.Session:
+		playing := 0
+		for _, session := range data {
+			if state := session.State(); state == "Playing" || state == "Paused" {
+				playing++
+			}
+		}
+		fmt.Fprintf(w, "%s: %d sessions, %d playing\n", prefix, len(data), playing)
+
+	case []models.ActivityLogItem:
+		for _, item := range data {
+			fmt.Fprintf(w, "%s: [%s] %s\n", prefix, item.Severity, item.Name)
+		}
+
+	case *models.LibraryUpdateInfo:
+		fmt.Fprintf(w, "%s: %d added, %d updated, %d removed\n",
+			prefix, len(data.ItemsAdded), len(data.ItemsUpdated), len(data.ItemsRemoved))
+
+	case *models.UserDataChangeInfo:
+		fmt.Fprintf(w, "%s: %d items changed for user %s\n", prefix, len(data.UserDataList), data.UserID)
+
+	case *models.TaskResult:
+		line := fmt.Sprintf("%s: %s %s in %s", prefix, data.Name, data.Status, data.Duration().Round(time.Second))
+		if data.ErrorMessage != "" {
+			line += " - " + data.ErrorMessage
+		}
+		fmt.Fprintln(w, line)
+
+	default:
+		if ev.Data == nil {
+			fmt.Fprintln(w, prefix)
+			return
+		}
+		raw, err := json.Marshal(ev.Data)
+		if err != nil {
+			fmt.Fprintln(w, prefix)
+			return
+		}
+		fmt.Fprintf(w, "%s: %s\n", prefix, raw)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+)
+
+// serverResult holds the outcome of a request against a single server
+type serverResult[T any] struct {
+	Server string
+	Value  T
+	Err    error
+}
+
+// targetServers returns the names of the servers a command should run against
+func targetServers() ([]string, error) {
+	if !allServers {
+		name, err := activeServerName()
+		if err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	}
+
+	if serverName != "" {
+		return nil, errors.New("--all-servers cannot be combined with --server")
+	}
+
+	return serverNames(), nil
+}
+
+// fanOut runs fn concurrently against every target server.
+// Results are returned in server order; failures are reported per server
+// and only cause an error when no server succeeded.
+func fanOut[T any](ctx context.Context, fn func(ctx context.Context, c client.Client) (T, error)) ([]serverResult[T], error) {
+	names, err := targetServers()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]serverResult[T], len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			server, _ := lookupServer(name)
+			c, err := serverClient(name, server)
+			if err != nil {
+				results[i] = serverResult[T]{Server: name, Err: err}
+				return
+			}
+
+			value, err := fn(ctx, c)
+			results[i] = serverResult[T]{Server: name, Value: value, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	// Against the active server, behave exactly like a plain request
+	if !allServers {
+		if results[0].Err != nil {
+			return nil, results[0].Err
+		}
+		results[0].Server = ""
+		return results, nil
+	}
+
+	succeeded := make([]serverResult[T], 0, len(results))
+	errs := make([]error, 0)
+	for _, result := range results {
+		if result.Err != nil {
+			logger.Errorw("Request failed", "server", result.Server, "error", result.Err)
+			errs = append(errs, fmt.Errorf("%s: %w", result.Server, result.Err))
+			continue
+		}
+		succeeded = append(succeeded, result)
+	}
+
+	if len(succeeded) == 0 {
+		return nil, fmt.Errorf("request failed on all %d servers: %w", len(results), errors.Join(errs...))
+	}
+
+	return succeeded, nil
+}
+
+// serverTag formats a server name for prefixing text output rows
+func serverTag(server string) string {
+	if server == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("[%s] ", server)
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// serverItem is a library item tagged with the server it was found on
+type serverItem struct {
+	Server string `json:"Server,omitempty"`
+	models.Item
+}
+
+// serverItems is a list of library items merged from one or more servers
+type serverItems struct {
+	Items            []serverItem `json:"Items"`
+	TotalRecordCount int          `json:"TotalRecordCount"`
+}
+
+// itemsCmd represents the items command
+var itemsCmd = &cobra.Command{
+	Use:   "items",
+	Short: "List library items on the Jellyfin server",
+	Long: `List items such as movies, series and episodes in the Jellyfin libraries.
+
+Filter by item type with --type, by library or folder ID with --parent,
+or by name with --search. Use --all to page through every item.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		itemTypes, _ := cmd.Flags().GetStringSlice("type")
+		parentID, _ := cmd.Flags().GetString("parent")
+		search, _ := cmd.Flags().GetString("search")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		opts, err := pageOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Set up the query
+		query := client.ItemQuery{
+			ParentID:         parentID,
+			Recursive:        recursive,
+			IncludeItemTypes: itemTypes,
+			SearchTerm:       search,
+			SortBy:           []string{"SortName"},
+			SortOrder:        "Ascending",
+			Fields:           []string{"Path", "DateCreated"},
+		}
+
+		// Get items
+		results, err := fanOut(cmd.Context(), func(ctx context.Context, c client.Client) (*models.QueryResult[models.Item], error) {
+			items, total, err := collect(ctx, c.PageItems(query, opts))
+			if err != nil {
+				return nil, err
+			}
+			return &models.QueryResult[models.Item]{Items: items, TotalRecordCount: total, StartIndex: opts.StartIndex}, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list items: %w", err)
+		}
+
+		items := &serverItems{Items: make([]serverItem, 0)}
+		for _, result := range results {
+			items.TotalRecordCount += result.Value.TotalRecordCount
+			for _, item := range result.Value.Items {
+				items.Items = append(items.Items, serverItem{Server: result.Server, Item: item})
+			}
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data:    items,
+			Rows:    items.Items,
+			Columns: withServerColumn("Type", "Name", "SeriesName", "ProductionYear", "Id"),
+			Text: func(w io.Writer) {
+				outputItemsText(w, items)
+			},
+		})
+	},
+}
+
+// refreshModes are the ways an item's metadata and images can be refreshed, from least to most thorough
+var refreshModes = []string{"None", "ValidationOnly", "Default", "FullRefresh"}
+
+// itemsRefreshCmd represents the items refresh command
+var itemsRefreshCmd = &cobra.Command{
+	Use:   "refresh [item-id]",
+	Short: "Refresh the metadata and images of an item",
+	Long: `Queue a refresh of a single item, such as a movie, series or season, instead of scanning every library.
+
+The refresh modes are None, ValidationOnly, Default or FullRefresh. Default looks for
+new and changed files and fills in missing metadata and images, while FullRefresh
+downloads them again. --replace-all-metadata and --replace-all-images discard what the
+item has, and imply FullRefresh. Use --recursive to also refresh a folder's items,
+such as a series' seasons and episodes.`,
+	Example: `  jellyfin-cli items refresh 4b3e9c0d5d2f4d7a8b1c2e3f4a5b6c7d --recursive
+  jellyfin-cli items refresh 4b3e9c0d5d2f4d7a8b1c2e3f4a5b6c7d --replace-all-images`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		opts, err := refreshOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		opts.Recursive = recursive
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Refresh item
+		if err := c.RefreshItem(cmd.Context(), args[0], opts); err != nil {
+			return fmt.Errorf("failed to refresh item %s: %w", args[0], err)
+		}
+
+		reportf("Refresh of item %s queued\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(itemsCmd)
+	itemsCmd.AddCommand(itemsRefreshCmd)
+
+	// Add local flags
+	itemsCmd.Flags().StringSliceP("type", "t", nil, "Filter by item types (Movie, Series, Episode, etc.)")
+	itemsCmd.Flags().String("parent", "", "Only list items in a library or folder, by ID")
+	itemsCmd.Flags().String("search", "", "Only list items whose name matches a search term")
+	itemsCmd.Flags().Bool("recursive", true, "Include items in subfolders")
+	addPagingFlags(itemsCmd, 50)
+
+	itemsRefreshCmd.Flags().Bool("recursive", false, "Also refresh the items within a folder, such as a series' episodes")
+	addRefreshFlags(itemsRefreshCmd)
+	addDryRunFlag(itemsRefreshCmd)
+}
+
+// addRefreshFlags adds the flags choosing how metadata and images are refreshed
+func addRefreshFlags(cmd *cobra.Command) {
+	modes := strings.Join(refreshModes, ", ")
+	cmd.Flags().String("metadata-mode", "Default", "How to refresh metadata: "+modes)
+	cmd.Flags().String("image-mode", "Default", "How to refresh images: "+modes)
+	cmd.Flags().Bool("replace-all-metadata", false, "Replace all existing metadata, implying --metadata-mode FullRefresh")
+	cmd.Flags().Bool("replace-all-images", false, "Replace all existing images, implying --image-mode FullRefresh")
+}
+
+// refreshOptionsFromFlags reads the flags added by addRefreshFlags
+func refreshOptionsFromFlags(cmd *cobra.Command) (client.RefreshOptions, error) {
+	// Get command flags
+	metadataMode, _ := cmd.Flags().GetString("metadata-mode")
+	imageMode, _ := cmd.Flags().GetString("image-mode")
+	replaceMetadata, _ := cmd.Flags().GetBool("replace-all-metadata")
+	replaceImages, _ := cmd.Flags().GetBool("replace-all-images")
+
+	metadataMode, err := parseRefreshMode("metadata-mode", metadataMode, replaceMetadata, cmd.Flags().Changed("metadata-mode"))
+	if err != nil {
+		return client.RefreshOptions{}, err
+	}
+	imageMode, err = parseRefreshMode("image-mode", imageMode, replaceImages, cmd.Flags().Changed("image-mode"))
+	if err != nil {
+		return client.RefreshOptions{}, err
+	}
+
+	return client.RefreshOptions{
+		MetadataRefreshMode: metadataMode,
+		ImageRefreshMode:    imageMode,
+		ReplaceAllMetadata:  replaceMetadata,
+		ReplaceAllImages:    replaceImages,
+	}, nil
+}
+
+// parseRefreshMode validates a refresh mode flag, ignoring case.
+// Replacing everything needs a full refresh, which is used unless another mode was chosen.
+func parseRefreshMode(flag, value string, replaceAll, changed bool) (string, error) {
+	index := slices.IndexFunc(refreshModes, func(mode string) bool { return strings.EqualFold(mode, value) })
+	if index < 0 {
+		return "", fmt.Errorf("invalid --%s %q, use one of %s", flag, value, strings.Join(refreshModes, ", "))
+	}
+	mode := refreshModes[index]
+
+	if replaceAll && mode != "FullRefresh" {
+		if changed {
+			return "", fmt.Errorf("--%s %s doesn't replace anything, use FullRefresh or leave it out", flag, mode)
+		}
+		mode = "FullRefresh"
+	}
+
+	return mode, nil
+}
+
+// outputItemsText outputs library items in human-readable format
+func outputItemsText(w io.Writer, items *serverItems) {
+	if items == nil || len(items.Items) == 0 {
+		fmt.Fprintln(w, "No items found")
+		return
+	}
+
+	fmt.Fprintf(w, "Items (Total: %d):\n", items.TotalRecordCount)
+
+	for i, item := range items.Items {
+		name := item.Name
+		switch {
+		case item.Type == "Episode" && item.SeriesName != "":
+			name = fmt.Sprintf("%s - %s", item.SeriesName, item.Name)
+			if item.SeasonNum > 0 && item.EpisodeNum > 0 {
+				name += fmt.Sprintf(" (S%02dE%02d)", item.SeasonNum, item.EpisodeNum)
+			}
+		case item.ProductionYear > 0:
+			name += fmt.Sprintf(" (%d)", item.ProductionYear)
+		}
+
+		fmt.Fprintf(w, " %d. %s[%s] %s\n", i+1, serverTag(item.Server), item.Type, name)
+	}
+}
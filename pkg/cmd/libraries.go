@@ -1,60 +1,158 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
 	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
 )
 
+// serverLibraryFolder is a library folder tagged with the server it was found on
+type serverLibraryFolder struct {
+	Server string `json:"Server,omitempty"`
+	models.LibraryFolder
+}
+
 // librariesCmd represents the libraries command
 var librariesCmd = &cobra.Command{
 	Use:   "libraries",
 	Short: "List library folders on the Jellyfin server",
 	Long:  `List all library folders (virtual folders) on the Jellyfin server.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get client
-		client := getClient()
-
-		// Get command flags
-		outputJSON, _ := cmd.Flags().GetBool("json")
-
 		// Get library folders
-		libraries, err := client.ListLibraryFolders(cmd.Context(), nil)
+		results, err := fanOut(cmd.Context(), func(ctx context.Context, c client.Client) ([]models.LibraryFolder, error) {
+			return c.ListLibraryFolders(ctx)
+		})
 		if err != nil {
 			return fmt.Errorf("failed to list library folders: %w", err)
 		}
 
-		// Output
-		if outputJSON {
-			outputLibrariesJSON(libraries)
-		} else {
-			outputLibrariesText(libraries)
+		libraries := make([]serverLibraryFolder, 0)
+		for _, result := range results {
+			for _, library := range result.Value {
+				libraries = append(libraries, serverLibraryFolder{Server: result.Server, LibraryFolder: library})
+			}
 		}
 
-		return nil
+		// Output
+		return printResult(output.Result{
+			Data:    libraries,
+			Columns: withServerColumn("Name", "CollectionType", "Locations", "RefreshStatus", "ItemId"),
+			Text: func(w io.Writer) {
+				outputLibrariesText(w, libraries)
+			},
+		})
 	},
 }
 
+// libraryScanTaskKey is the key of the scheduled task that scans the libraries
+const libraryScanTaskKey = "RefreshLibrary"
+
 // refreshCmd represents the refresh command
 var refreshCmd = &cobra.Command{
-	Use:   "refresh",
+	Use:   "refresh [library]",
 	Short: "Refresh the library",
-	Long:  `Refresh the Jellyfin library to scan for new content.`,
+	Long: `Refresh the Jellyfin library to scan for new content.
+
+Name a library, by name or ID, to only scan that library. Its metadata and images
+are then refreshed as chosen with --metadata-mode, --image-mode,
+--replace-all-metadata and --replace-all-images (see 'jellyfin-cli items refresh --help').
+
+With --wait, the command runs the "Scan Media Library" scheduled task and follows it,
+showing a progress bar, and blocks until the scan finishes. When a scan is already
+running, no new one is started and the running scan is followed instead. It then prints how long the scan
+took and exits with a non-zero status when it failed or was cancelled, so scripts can
+run further steps once new content is in the library.`,
+	Example: `  jellyfin-cli libraries refresh
+  jellyfin-cli libraries refresh --wait --timeout 2h
+  jellyfin-cli libraries refresh Movies --image-mode FullRefresh`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		opts, err := refreshOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			for _, flag := range []string{"metadata-mode", "image-mode", "replace-all-metadata", "replace-all-images"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s only applies when refreshing a single library", flag)
+				}
+			}
+		} else if wait {
+			return errors.New("--wait only follows scans of every library")
+		}
+
 		// Get client
-		client := getClient()
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		// Refresh a single library
+		if len(args) > 0 {
+			library, err := findLibraryFolder(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+
+			opts.Recursive = true
+			if err := client.RefreshItem(cmd.Context(), library.ItemID, opts); err != nil {
+				return fmt.Errorf("failed to refresh library %s: %w", library.Name, err)
+			}
+
+			reportf("Refresh of library %s queued\n", library.Name)
+			return nil
+		}
+
+		if !wait {
+			// Refresh library
+			if err := client.RefreshLibrary(cmd.Context()); err != nil {
+				return fmt.Errorf("failed to refresh library: %w", err)
+			}
 
-		// Refresh library
-		err := client.RefreshLibrary(cmd.Context())
+			reportf("Library refresh initiated successfully\n")
+			return nil
+		}
+
+		// Run the scan as a scheduled task, so this run can be told apart from the previous one
+		scan, err := resolveTask(cmd.Context(), client, libraryScanTaskKey)
 		if err != nil {
-			return fmt.Errorf("failed to refresh library: %w", err)
+			return err
+		}
+		var since time.Time
+		if scan.LastExecutionResult != nil {
+			since = scan.LastExecutionResult.EndTimeUTC
+		}
+
+		if scan.State == models.TaskStateIdle {
+			if err := client.StartScheduledTask(cmd.Context(), scan.ID); err != nil {
+				return fmt.Errorf("failed to refresh library: %w", err)
+			}
+		} else {
+			reportf("A library scan is already running, waiting for it to finish\n")
 		}
 
-		fmt.Println("Library refresh initiated successfully")
-		return nil
+		if dryRun {
+			return nil
+		}
+
+		ctx, cancel := withWaitTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		return waitForTaskResult(ctx, client, scan, since, interval)
 	},
 }
 
@@ -63,31 +161,43 @@ func init() {
 
 	// Add refresh as a subcommand of libraries
 	librariesCmd.AddCommand(refreshCmd)
+
+	// Add local flags
+	refreshCmd.Flags().Bool("wait", false, "Wait for the library scan to finish, failing if it doesn't complete")
+	refreshCmd.Flags().Duration("timeout", 0, "With --wait, stop waiting after this long, e.g. 2h (default is no limit)")
+	refreshCmd.Flags().Duration("interval", defaultTaskPollInterval, "With --wait, how often to check the scan's progress")
+	addRefreshFlags(refreshCmd)
+	addDryRunFlag(refreshCmd)
 }
 
-// outputLibrariesText outputs libraries in human-readable format
-func outputLibrariesText(libraries []models.LibraryFolder) {
-	if len(libraries) == 0 {
-		fmt.Println("No library folders found")
-		return
+// findLibraryFolder finds a library folder by name or ID
+func findLibraryFolder(ctx context.Context, c client.Client, name string) (models.LibraryFolder, error) {
+	libraries, err := c.ListLibraryFolders(ctx)
+	if err != nil {
+		return models.LibraryFolder{}, fmt.Errorf("failed to list library folders: %w", err)
 	}
 
-	fmt.Println("Library Folders:")
 	for _, library := range libraries {
-		fmt.Printf(" - %s (Type: %s)\n", library.Name, library.CollectionType)
-		if library.RefreshStatus != "" {
-			fmt.Printf("   Status: %s\n", library.RefreshStatus)
+		if strings.EqualFold(library.Name, name) || strings.EqualFold(library.ItemID, name) {
+			return library, nil
 		}
 	}
+
+	return models.LibraryFolder{}, fmt.Errorf("library %q not found, see 'jellyfin-cli libraries'", name)
 }
 
-// outputLibrariesJSON outputs libraries in JSON format
-func outputLibrariesJSON(libraries []models.LibraryFolder) {
-	jsonBytes, err := json.MarshalIndent(libraries, "", "  ")
-	if err != nil {
-		logger.Errorw("Failed to marshal libraries to JSON", "error", err)
+// outputLibrariesText outputs libraries in human-readable format
+func outputLibrariesText(w io.Writer, libraries []serverLibraryFolder) {
+	if len(libraries) == 0 {
+		fmt.Fprintln(w, "No library folders found")
 		return
 	}
 
-	fmt.Println(string(jsonBytes))
+	fmt.Fprintln(w, "Library Folders:")
+	for _, library := range libraries {
+		fmt.Fprintf(w, " - %s%s (Type: %s)\n", serverTag(library.Server), library.Name, library.CollectionType)
+		if library.RefreshStatus != "" {
+			fmt.Fprintf(w, "   Status: %s\n", library.RefreshStatus)
+		}
+	}
 }
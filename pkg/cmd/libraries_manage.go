@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+)
+
+// librariesCreateCmd represents the libraries create command
+var librariesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a library",
+	Long: `Create a library folder holding one type of content, read from one or more paths on the server.
+
+The type is one of movies, tvshows, music, musicvideos, homevideos, boxsets, books or mixed.
+Paths are folders on the Jellyfin server, not on the machine running this command.`,
+	Example: `  jellyfin-cli libraries create --name Movies --type movies --path /media/movies
+  jellyfin-cli libraries create --name Shows --type tvshows --path /media/tv --path /mnt/archive/tv --refresh`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		name, _ := cmd.Flags().GetString("name")
+		collectionType, _ := cmd.Flags().GetString("type")
+		paths, _ := cmd.Flags().GetStringArray("path")
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		collectionType = strings.ToLower(collectionType)
+		if !slices.Contains(models.LibraryCollectionTypes, collectionType) {
+			return fmt.Errorf("invalid --type %q, use one of %s", collectionType, strings.Join(models.LibraryCollectionTypes, ", "))
+		}
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		if err := c.CreateLibraryFolder(cmd.Context(), name, collectionType, paths, refresh); err != nil {
+			return fmt.Errorf("failed to create library %s: %w", name, err)
+		}
+
+		reportf("Created library %s\n", name)
+		return nil
+	},
+}
+
+// librariesRenameCmd represents the libraries rename command
+var librariesRenameCmd = &cobra.Command{
+	Use:   "rename [library] [new-name]",
+	Short: "Rename a library",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := c.RenameLibraryFolder(cmd.Context(), library.Name, args[1], refresh); err != nil {
+			return fmt.Errorf("failed to rename library %s: %w", library.Name, err)
+		}
+
+		reportf("Renamed library %s to %s\n", library.Name, args[1])
+		return nil
+	},
+}
+
+// librariesDeleteCmd represents the libraries delete command
+var librariesDeleteCmd = &cobra.Command{
+	Use:   "delete [library]",
+	Short: "Delete a library",
+	Long: `Delete a library folder, removing its items and their metadata from Jellyfin.
+The media files on the server are left in place.
+
+Asks for confirmation unless --yes is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := confirm(cmd, "delete library %s (its media files are kept)", library.Name); err != nil {
+			return err
+		}
+
+		if err := c.DeleteLibraryFolder(cmd.Context(), library.Name, refresh); err != nil {
+			return fmt.Errorf("failed to delete library %s: %w", library.Name, err)
+		}
+
+		reportf("Deleted library %s\n", library.Name)
+		return nil
+	},
+}
+
+// librariesPathsCmd represents the libraries paths command
+var librariesPathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Change the folders a library reads media from",
+	Long: `Add, remove or replace the folders on the server that a library reads media from.
+
+Libraries can be named by name or ID.`,
+}
+
+// librariesPathsAddCmd represents the libraries paths add command
+var librariesPathsAddCmd = &cobra.Command{
+	Use:     "add [library] [path...]",
+	Short:   "Add folders to a library",
+	Example: `  jellyfin-cli libraries paths add Movies /mnt/new-disk/movies --refresh`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Only scan once the last path is added
+		paths := args[1:]
+		for i, path := range paths {
+			if err := c.AddLibraryPath(cmd.Context(), library.Name, path, refresh && i == len(paths)-1); err != nil {
+				return fmt.Errorf("failed to add %s to library %s: %w", path, library.Name, err)
+			}
+			reportf("Added %s to library %s\n", path, library.Name)
+		}
+
+		return nil
+	},
+}
+
+// librariesPathsRemoveCmd represents the libraries paths remove command
+var librariesPathsRemoveCmd = &cobra.Command{
+	Use:   "remove [library] [path...]",
+	Short: "Remove folders from a library",
+	Long: `Remove folders from a library. The items found in them are removed from Jellyfin
+on the next scan, while the media files on the server are left in place.
+
+Asks for confirmation unless --yes is given.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		paths := args[1:]
+		if err := confirm(cmd, "remove %s from library %s", strings.Join(paths, ", "), library.Name); err != nil {
+			return err
+		}
+
+		// Only scan once the last path is removed
+		for i, path := range paths {
+			if err := c.RemoveLibraryPath(cmd.Context(), library.Name, path, refresh && i == len(paths)-1); err != nil {
+				return fmt.Errorf("failed to remove %s from library %s: %w", path, library.Name, err)
+			}
+			reportf("Removed %s from library %s\n", path, library.Name)
+		}
+
+		return nil
+	},
+}
+
+// librariesPathsReplaceCmd represents the libraries paths replace command
+var librariesPathsReplaceCmd = &cobra.Command{
+	Use:     "replace [library] [path] [new-path]",
+	Aliases: []string{"update"},
+	Short:   "Replace a folder of a library",
+	Long: `Replace one of a library's folders with another, such as after moving media to a new disk.
+
+The server can't change a folder's path, so the new folder is added and the old one is
+then removed, leaving the library a folder to read from throughout. This is not atomic:
+when removing the old folder fails, the library keeps both and the old one has to be
+removed with 'jellyfin-cli libraries paths remove'.
+
+Asks for confirmation unless --yes is given.`,
+	Example: `  jellyfin-cli libraries paths replace Movies /mnt/old-disk/movies /mnt/new-disk/movies --refresh`,
+	Args:    cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		oldPath, newPath := args[1], args[2]
+		if oldPath == newPath {
+			return errors.New("the new path is the same as the old one")
+		}
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := confirm(cmd, "replace %s with %s in library %s", oldPath, newPath, library.Name); err != nil {
+			return err
+		}
+
+		if err := c.AddLibraryPath(cmd.Context(), library.Name, newPath, false); err != nil {
+			return fmt.Errorf("failed to add %s to library %s: %w", newPath, library.Name, err)
+		}
+		if err := c.RemoveLibraryPath(cmd.Context(), library.Name, oldPath, refresh); err != nil {
+			return fmt.Errorf("added %s, but failed to remove %s from library %s, which now has both: %w", newPath, oldPath, library.Name, err)
+		}
+
+		reportf("Replaced %s with %s in library %s\n", oldPath, newPath, library.Name)
+		return nil
+	},
+}
+
+func init() {
+	librariesCmd.AddCommand(librariesCreateCmd)
+	librariesCmd.AddCommand(librariesRenameCmd)
+	librariesCmd.AddCommand(librariesDeleteCmd)
+	librariesCmd.AddCommand(librariesPathsCmd)
+	librariesPathsCmd.AddCommand(librariesPathsAddCmd)
+	librariesPathsCmd.AddCommand(librariesPathsRemoveCmd)
+	librariesPathsCmd.AddCommand(librariesPathsReplaceCmd)
+
+	// Add local flags
+	librariesCreateCmd.Flags().String("name", "", "Name of the library")
+	librariesCreateCmd.Flags().String("type", "", "Type of content: "+strings.Join(models.LibraryCollectionTypes, ", "))
+	librariesCreateCmd.Flags().StringArray("path", nil, "Folder on the server to read media from (repeatable)")
+	_ = librariesCreateCmd.MarkFlagRequired("name")
+	_ = librariesCreateCmd.MarkFlagRequired("type")
+
+	for _, cmd := range []*cobra.Command{librariesDeleteCmd, librariesPathsRemoveCmd, librariesPathsReplaceCmd} {
+		addYesFlag(cmd)
+	}
+
+	for _, cmd := range []*cobra.Command{librariesCreateCmd, librariesRenameCmd, librariesDeleteCmd, librariesPathsAddCmd, librariesPathsRemoveCmd, librariesPathsReplaceCmd} {
+		cmd.Flags().Bool("refresh", false, "Scan the libraries once the change is made")
+		addDryRunFlag(cmd)
+	}
+}
@@ -0,0 +1,564 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// typeOptionFields are the settings that choose a library's providers for one type of item
+var typeOptionFields = []string{"MetadataFetchers", "MetadataFetcherOrder", "ImageFetchers", "ImageFetcherOrder"}
+
+// librariesShowCmd represents the libraries show command
+var librariesShowCmd = &cobra.Command{
+	Use:   "show [library]",
+	Short: "Show the settings of a library",
+	Long: `Show a library's folders, metadata language and country, metadata providers,
+real-time monitoring and chapter image settings.
+
+Use -o json to see every setting by the name 'libraries options set' takes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data: library,
+			Text: func(w io.Writer) {
+				outputLibraryDetailText(w, library)
+			},
+		})
+	},
+}
+
+// librariesOptionsCmd represents the libraries options command
+var librariesOptionsCmd = &cobra.Command{
+	Use:   "options",
+	Short: "Change the settings of a library",
+}
+
+// librariesOptionsSetCmd represents the libraries options set command
+var librariesOptionsSetCmd = &cobra.Command{
+	Use:   "set [library] [setting=value...]",
+	Short: "Change the settings of a library",
+	Long: `Change a library's settings, named as in 'jellyfin-cli libraries show <library> -o json'.
+Names are case-insensitive, and other settings are left as they are. Settings this
+command doesn't know about keep the type the server sent them as, and objects take JSON.
+
+Settings take true or false, a number, or text. Lists are separated by commas,
+and an empty value clears a list. The providers of one type of item are set with
+<type>.MetadataFetchers, <type>.MetadataFetcherOrder, <type>.ImageFetchers and
+<type>.ImageFetcherOrder, e.g. Movie.MetadataFetchers.
+
+Providers, languages and countries are checked against the ones the server offers.
+The folders of a library are changed with 'jellyfin-cli libraries paths'.`,
+	Example: `  jellyfin-cli libraries options set Movies EnableRealtimeMonitor=true
+  jellyfin-cli libraries options set Movies PreferredMetadataLanguage=de MetadataCountryCode=DE
+  jellyfin-cli libraries options set Shows "Series.MetadataFetchers=TheMovieDb,The Open Movie Database"
+  jellyfin-cli libraries options set Movies EnableChapterImageExtraction=true ExtractChapterImagesDuringLibraryScan=false`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		library, err := findLibraryFolder(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Apply every setting before saving, so a mistake leaves the library unchanged
+		options := library.LibraryOptions
+		setter := &libraryOptionSetter{ctx: cmd.Context(), client: c, library: library, options: &options}
+		changes := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok {
+				return fmt.Errorf("invalid setting %q, use setting=value", arg)
+			}
+
+			change, err := setter.set(strings.TrimSpace(key), strings.TrimSpace(value))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change)
+		}
+
+		if err := c.UpdateLibraryOptions(cmd.Context(), library.ItemID, options); err != nil {
+			return fmt.Errorf("failed to update library %s: %w", library.Name, err)
+		}
+
+		for _, change := range changes {
+			reportf("Set %s on library %s\n", change, library.Name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	librariesCmd.AddCommand(librariesShowCmd)
+	librariesCmd.AddCommand(librariesOptionsCmd)
+	librariesOptionsCmd.AddCommand(librariesOptionsSetCmd)
+
+	// Add local flags
+	addDryRunFlag(librariesOptionsSetCmd)
+}
+
+// libraryOptionSetter changes library options, validating them against what the server offers.
+// The server's offers are only requested once they are needed.
+type libraryOptionSetter struct {
+	ctx     context.Context
+	client  client.Client
+	library models.LibraryFolder
+	options *models.LibraryOptions
+
+	available *models.AvailableLibraryOptions
+	cultures  []models.Culture
+	countries []models.Country
+}
+
+// set changes one setting, returning a description of the change
+func (s *libraryOptionSetter) set(key, value string) (string, error) {
+	if itemType, name, ok := strings.Cut(key, "."); ok {
+		return s.setTypeOption(itemType, name, value)
+	}
+
+	field, ok := libraryOptionField(key)
+	if !ok {
+		if name, ok := s.extraOption(key); ok {
+			return s.setExtraOption(name, value)
+		}
+		return "", fmt.Errorf("unknown library setting %q, see 'jellyfin-cli libraries show %s -o json'", key, s.library.Name)
+	}
+	name := field.Tag.Get("json")
+	target := reflect.ValueOf(s.options).Elem().FieldByIndex(field.Index)
+
+	switch name {
+	case "PathInfos":
+		return "", errors.New("library folders are changed with 'jellyfin-cli libraries paths'")
+	case "TypeOptions":
+		return "", errors.New("providers are set per type of item, e.g. Movie.MetadataFetchers=TheMovieDb")
+	}
+
+	switch target.Kind() {
+	case reflect.Bool:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s %q, use true or false", name, value)
+		}
+		target.SetBool(enabled)
+
+	case reflect.Int:
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return "", fmt.Errorf("invalid %s %q, use a whole number of zero or more", name, value)
+		}
+		target.SetInt(int64(number))
+
+	case reflect.String:
+		value, err := s.checkValue(name, value)
+		if err != nil {
+			return "", err
+		}
+		target.SetString(value)
+
+	case reflect.Slice:
+		values, err := s.checkList(name, splitList(value))
+		if err != nil {
+			return "", err
+		}
+		target.Set(reflect.ValueOf(values))
+
+	default:
+		return "", fmt.Errorf("library setting %s can't be changed with this command", name)
+	}
+	s.options.MarkChanged(name)
+
+	return fmt.Sprintf("%s to %s", name, formatOptionValue(target)), nil
+}
+
+// setTypeOption changes the providers used for one type of item
+func (s *libraryOptionSetter) setTypeOption(itemType, key, value string) (string, error) {
+	index := slices.IndexFunc(typeOptionFields, func(name string) bool { return strings.EqualFold(name, key) })
+	if index < 0 {
+		return "", fmt.Errorf("unknown setting %s.%s, use one of %s", itemType, key, strings.Join(typeOptionFields, ", "))
+	}
+	name := typeOptionFields[index]
+
+	available, err := s.availableOptions()
+	if err != nil {
+		return "", err
+	}
+	typeIndex := slices.IndexFunc(available.TypeOptions, func(info models.LibraryTypeOptionsInfo) bool {
+		return strings.EqualFold(info.Type, itemType)
+	})
+	if typeIndex < 0 {
+		types := make([]string, 0, len(available.TypeOptions))
+		for _, info := range available.TypeOptions {
+			types = append(types, info.Type)
+		}
+		return "", fmt.Errorf("library %s has no item type %q, use one of %s", s.library.Name, itemType, strings.Join(types, ", "))
+	}
+	info := available.TypeOptions[typeIndex]
+
+	offered := info.MetadataFetchers
+	if strings.HasPrefix(name, "Image") {
+		offered = info.ImageFetchers
+	}
+	values, err := checkProviders(info.Type+"."+name, splitList(value), offered)
+	if err != nil {
+		return "", err
+	}
+
+	options := s.options.TypeOptionsFor(info.Type)
+	if options == nil {
+		s.options.TypeOptions = append(s.options.TypeOptions, models.LibraryTypeOptions{Type: info.Type})
+		options = &s.options.TypeOptions[len(s.options.TypeOptions)-1]
+	}
+	target := reflect.ValueOf(options).Elem().FieldByName(name)
+	target.Set(reflect.ValueOf(values))
+	s.options.MarkChanged("TypeOptions")
+
+	return fmt.Sprintf("%s.%s to %s", info.Type, name, formatOptionValue(target)), nil
+}
+
+// extraOption finds a setting this command doesn't know about by its case-insensitive name
+func (s *libraryOptionSetter) extraOption(key string) (string, bool) {
+	for name := range s.options.Extra {
+		if strings.EqualFold(name, key) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// setExtraOption changes a setting this command doesn't know about, keeping the JSON type
+// the server sent it as. Objects and other values that can't be told apart take JSON.
+func (s *libraryOptionSetter) setExtraOption(name, value string) (string, error) {
+	current := bytes.TrimSpace(s.options.Extra[name])
+
+	var encoded any
+	var list []string
+	switch {
+	case bytes.Equal(current, []byte("true")) || bytes.Equal(current, []byte("false")):
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s %q, use true or false", name, value)
+		}
+		encoded = enabled
+
+	case bytes.HasPrefix(current, []byte(`"`)):
+		encoded = value
+
+	case json.Unmarshal(current, &list) == nil && list != nil:
+		encoded = splitList(value)
+
+	case json.Valid(current) && strings.ContainsAny(string(current[:1]), "-0123456789"):
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("invalid %s %q, use a number", name, value)
+		}
+		encoded = json.Number(value)
+
+	default:
+		if !json.Valid([]byte(value)) {
+			return "", fmt.Errorf("invalid %s %q, use a JSON value", name, value)
+		}
+		encoded = json.RawMessage(value)
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	s.options.Extra[name] = data
+	s.options.MarkChanged(name)
+
+	return fmt.Sprintf("%s to %s", name, data), nil
+}
+
+// checkValue validates a text setting, returning it as the server spells it
+func (s *libraryOptionSetter) checkValue(name, value string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+
+	switch name {
+	case "PreferredMetadataLanguage":
+		cultures, err := s.listCultures()
+		if err != nil {
+			return "", err
+		}
+		for _, culture := range cultures {
+			if strings.EqualFold(culture.TwoLetterISOLanguageName, value) {
+				return culture.TwoLetterISOLanguageName, nil
+			}
+		}
+		return "", fmt.Errorf("invalid %s %q, use a two-letter language code such as en", name, value)
+
+	case "MetadataCountryCode":
+		countries, err := s.listCountries()
+		if err != nil {
+			return "", err
+		}
+		for _, country := range countries {
+			if strings.EqualFold(country.TwoLetterISORegionName, value) {
+				return country.TwoLetterISORegionName, nil
+			}
+		}
+		return "", fmt.Errorf("invalid %s %q, use a two-letter country code such as US", name, value)
+
+	default:
+		return value, nil
+	}
+}
+
+// checkList validates a list setting, returning it as the server spells it
+func (s *libraryOptionSetter) checkList(name string, values []string) ([]string, error) {
+	if len(values) == 0 {
+		return values, nil
+	}
+
+	var offered func(*models.AvailableLibraryOptions) []models.LibraryOptionInfo
+	switch name {
+	case "MetadataSavers":
+		offered = func(a *models.AvailableLibraryOptions) []models.LibraryOptionInfo { return a.MetadataSavers }
+	case "DisabledLocalMetadataReaders", "LocalMetadataReaderOrder":
+		offered = func(a *models.AvailableLibraryOptions) []models.LibraryOptionInfo { return a.MetadataReaders }
+	case "DisabledSubtitleFetchers", "SubtitleFetcherOrder":
+		offered = func(a *models.AvailableLibraryOptions) []models.LibraryOptionInfo { return a.SubtitleFetchers }
+	case "DisabledLyricFetchers", "LyricFetcherOrder":
+		offered = func(a *models.AvailableLibraryOptions) []models.LibraryOptionInfo { return a.LyricFetchers }
+	case "SubtitleDownloadLanguages":
+		return s.checkSubtitleLanguages(name, values)
+	default:
+		return values, nil
+	}
+
+	available, err := s.availableOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return checkProviders(name, values, offered(available))
+}
+
+// checkSubtitleLanguages validates three-letter language codes
+func (s *libraryOptionSetter) checkSubtitleLanguages(name string, values []string) ([]string, error) {
+	cultures, err := s.listCultures()
+	if err != nil {
+		return nil, err
+	}
+
+	checked := make([]string, 0, len(values))
+	for _, value := range values {
+		index := slices.IndexFunc(cultures, func(culture models.Culture) bool {
+			return strings.EqualFold(culture.ThreeLetterISOLanguageName, value)
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("invalid %s %q, use three-letter language codes such as eng", name, value)
+		}
+		checked = append(checked, cultures[index].ThreeLetterISOLanguageName)
+	}
+
+	return checked, nil
+}
+
+// availableOptions returns the providers the library can use
+func (s *libraryOptionSetter) availableOptions() (*models.AvailableLibraryOptions, error) {
+	if s.available == nil {
+		available, err := s.client.GetAvailableLibraryOptions(s.ctx, s.library.CollectionType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the options available to library %s: %w", s.library.Name, err)
+		}
+		s.available = available
+	}
+
+	return s.available, nil
+}
+
+// listCultures returns the languages known to the server
+func (s *libraryOptionSetter) listCultures() ([]models.Culture, error) {
+	if s.cultures == nil {
+		cultures, err := s.client.ListCultures(s.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list languages: %w", err)
+		}
+		s.cultures = cultures
+	}
+
+	return s.cultures, nil
+}
+
+// listCountries returns the countries known to the server
+func (s *libraryOptionSetter) listCountries() ([]models.Country, error) {
+	if s.countries == nil {
+		countries, err := s.client.ListCountries(s.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list countries: %w", err)
+		}
+		s.countries = countries
+	}
+
+	return s.countries, nil
+}
+
+// checkProviders checks that providers are offered by the server, returning them as the server spells them
+func checkProviders(name string, values []string, offered []models.LibraryOptionInfo) ([]string, error) {
+	checked := make([]string, 0, len(values))
+	for _, value := range values {
+		index := slices.IndexFunc(offered, func(info models.LibraryOptionInfo) bool { return strings.EqualFold(info.Name, value) })
+		if index < 0 {
+			names := make([]string, 0, len(offered))
+			for _, info := range offered {
+				names = append(names, info.Name)
+			}
+			if len(names) == 0 {
+				return nil, fmt.Errorf("invalid %s %q, the server offers none", name, value)
+			}
+			return nil, fmt.Errorf("invalid %s %q, use one of %s", name, value, strings.Join(names, ", "))
+		}
+		checked = append(checked, offered[index].Name)
+	}
+
+	return checked, nil
+}
+
+// libraryOptionField finds a library setting by its case-insensitive JSON name
+func libraryOptionField(key string) (reflect.StructField, bool) {
+	t := reflect.TypeFor[models.LibraryOptions]()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("json")
+		if field.IsExported() && name != "-" && strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// splitList splits a comma-separated value, where an empty value is an empty list
+func splitList(value string) []string {
+	values := make([]string, 0)
+	for part := range strings.SplitSeq(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+
+	return values
+}
+
+// formatOptionValue formats a setting's new value for the confirmation of a change
+func formatOptionValue(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.Slice && v.Len() == 0:
+		return "(none)"
+	case v.Kind() == reflect.Slice:
+		return strings.Join(v.Interface().([]string), ", ")
+	case v.Kind() == reflect.String && v.Len() == 0:
+		return "(server default)"
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// outputLibraryDetailText outputs a library's settings in human-readable format
+func outputLibraryDetailText(w io.Writer, library models.LibraryFolder) {
+	options := library.LibraryOptions
+	field := func(label string, value any) {
+		fmt.Fprintf(w, "  %-22s %v\n", label+":", value)
+	}
+	enabled := func(on bool) string {
+		if on {
+			return "enabled"
+		}
+		return "disabled"
+	}
+	list := func(values []string) string {
+		if len(values) == 0 {
+			return "none"
+		}
+		return strings.Join(values, ", ")
+	}
+	orDefault := func(value string) string {
+		if value == "" {
+			return "server default"
+		}
+		return value
+	}
+
+	fmt.Fprintf(w, "Library %s\n", library.Name)
+	field("ID", library.ItemID)
+	field("Type", orDefault(library.CollectionType))
+	status := library.RefreshStatus
+	if library.RefreshProgress != nil && library.RefreshStatus != "Idle" {
+		status += fmt.Sprintf(" %.1f%%", *library.RefreshProgress)
+	}
+	field("Status", status)
+	field("Enabled", options.Enabled)
+
+	fmt.Fprintln(w, "Paths:")
+	if len(library.Locations) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, location := range library.Locations {
+		fmt.Fprintf(w, "  - %s\n", location)
+	}
+
+	fmt.Fprintln(w, "Metadata:")
+	field("Language", orDefault(options.PreferredMetadataLanguage))
+	field("Country", orDefault(options.MetadataCountryCode))
+	field("Savers", list(options.MetadataSavers))
+	field("Save with media", options.SaveLocalMetadata)
+	if options.AutomaticRefreshIntervalDays > 0 {
+		field("Refresh", fmt.Sprintf("every %d days", options.AutomaticRefreshIntervalDays))
+	} else {
+		field("Refresh", "never")
+	}
+	field("Real-time monitoring", enabled(options.EnableRealtimeMonitor))
+
+	fmt.Fprintln(w, "Chapter Images:")
+	field("Extraction", enabled(options.EnableChapterImageExtraction))
+	field("During library scan", options.ExtractChapterImagesDuringLibraryScan)
+
+	if len(options.TypeOptions) > 0 {
+		fmt.Fprintln(w, "Fetchers:")
+	}
+	for _, typeOptions := range options.TypeOptions {
+		fmt.Fprintf(w, "  %s:\n", typeOptions.Type)
+		field("  Metadata", list(typeOptions.MetadataFetchers))
+		field("  Images", list(typeOptions.ImageFetchers))
+	}
+
+	if len(options.DisabledSubtitleFetchers) > 0 || len(options.SubtitleDownloadLanguages) > 0 {
+		fmt.Fprintln(w, "Subtitles:")
+		field("Languages", list(options.SubtitleDownloadLanguages))
+		field("Disabled fetchers", list(options.DisabledSubtitleFetchers))
+	}
+}
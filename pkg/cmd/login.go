@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+)
+
+// loginCmd represents the login command
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with a username and password",
+	Long: `Log in to the Jellyfin server with a username and password.
+
+The access token and user ID returned by the server are saved to the config file,
+so there is no need to create an API key in the admin dashboard.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		username, _ := cmd.Flags().GetString("username")
+		passwordStdin, _ := cmd.Flags().GetBool("password-stdin")
+		baseURL, _ := cmd.Flags().GetString("url")
+
+		name, server, err := activeServer()
+		if err != nil {
+			return err
+		}
+		if baseURL != "" {
+			server.BaseURL = baseURL
+		}
+
+		// Prompt for anything not given on the command line
+		reader := bufio.NewReader(os.Stdin)
+		if username == "" {
+			if username, err = promptLine(reader, "Username: "); err != nil {
+				return err
+			}
+		}
+		if username == "" {
+			return errors.New("username is required")
+		}
+
+		password, err := readPassword(reader, passwordStdin)
+		if err != nil {
+			return err
+		}
+
+		// Reuse the saved device ID, or create one on the first login, and replace any previous token
+		if server.DeviceID == "" {
+			if server.DeviceID, err = newDeviceID(); err != nil {
+				return err
+			}
+		}
+		server.Token = ""
+
+		c, err := newClient(name, server)
+		if err != nil {
+			return err
+		}
+
+		result, err := c.AuthenticateByName(cmd.Context(), username, password)
+		if err != nil {
+			return fmt.Errorf("failed to log in: %w", err)
+		}
+
+		// Save the token to the config file
+		key := configKeyFor(name)
+		err = updateConfig(func(settings configSettings) error {
+			if baseURL != "" {
+				settings.Set(key+".base_url", baseURL)
+			}
+			settings.Set(key+".token", result.AccessToken)
+			settings.Set(key+".user_id", result.User.ID)
+			settings.Set(key+".device_id", server.DeviceID)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save access token: %w", err)
+		}
+
+		fmt.Printf("Logged in to %s as %s\n", name, result.User.Name)
+		return nil
+	},
+}
+
+// logoutCmd represents the logout command
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Log out and revoke the saved access token",
+	Long: `Log out of the Jellyfin server, revoking the saved access token and removing it from the config file.
+
+A token the server has already revoked, or that has expired, is removed from the config file as well.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, server, err := activeServer()
+		if err != nil {
+			return err
+		}
+		if server.Token == "" {
+			return fmt.Errorf("not logged in to %s", name)
+		}
+
+		// Revoke the session on the server
+		c, err := newClient(name, server)
+		if err != nil {
+			return err
+		}
+
+		// A token the server no longer knows is as good as revoked, so it is still removed locally
+		message := "Logged out successfully"
+		if err := c.Logout(cmd.Context()); err != nil {
+			if !client.IsUnauthorized(err) && !client.IsNotFound(err) {
+				return fmt.Errorf("failed to log out: %w", err)
+			}
+			logger.Debugw("Server rejected the logout", "server", name, "error", err)
+			message = "Logged out; the access token was already revoked or had expired"
+		}
+
+		// Remove the token from the config file
+		key := configKeyFor(name)
+		err = updateConfig(func(settings configSettings) error {
+			settings.Set(key+".token", "")
+			settings.Set(key+".user_id", "")
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove access token: %w", err)
+		}
+
+		fmt.Println(message)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+
+	// Add local flags
+	loginCmd.Flags().StringP("username", "u", "", "Username to log in with")
+	loginCmd.Flags().Bool("password-stdin", false, "Read the password from stdin")
+	loginCmd.Flags().String("url", "", "Base URL of the Jellyfin server (saved to the config file)")
+}
+
+// promptLine prints a prompt to stderr and reads a single line of input
+func promptLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// readPassword reads a password from stdin, without echo when attached to a terminal
+func readPassword(reader *bufio.Reader, fromStdin bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if fromStdin || !term.IsTerminal(fd) {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return string(password), nil
+}
+
+// newDeviceID generates a random device ID for this installation
+func newDeviceID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate device ID: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
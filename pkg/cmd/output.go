@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/viper"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+var (
+	outputColumns   []string
+	outputSortBy    string
+	outputNoHeaders bool
+	outputQuery     string
+)
+
+// newPrinter returns a printer writing to w in the output format selected by flags and config.
+// Color and width are always decided by stdout, so buffered output renders like direct output.
+func newPrinter(w io.Writer) (*output.Printer, error) {
+	format := viper.GetString("output.format")
+	if viper.GetBool("output.json") {
+		format = string(output.FormatJSON)
+	}
+
+	opts, err := output.ParseOptions(format)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Color, err = output.ParseColorMode(viper.GetString("output.color")); err != nil {
+		return nil, err
+	}
+	if output.ColorEnabled(opts.Color, os.Stdout) {
+		opts.Color = output.ColorAlways
+	} else {
+		opts.Color = output.ColorNever
+	}
+	opts.Width = output.TerminalWidth(os.Stdout)
+	opts.Columns = outputColumns
+	opts.SortBy = outputSortBy
+	opts.NoHeaders = outputNoHeaders
+	opts.Query = outputQuery
+
+	return output.NewPrinter(w, opts)
+}
+
+// printResult writes a command result to stdout in the selected output format
+func printResult(result output.Result) error {
+	return printResultTo(os.Stdout, result)
+}
+
+// printResultTo writes a command result to w in the selected output format
+func printResultTo(w io.Writer, result output.Result) error {
+	printer, err := newPrinter(w)
+	if err != nil {
+		return err
+	}
+
+	return printer.Print(result)
+}
+
+// withServerColumn prepends the server column to a column list when merging servers
+func withServerColumn(columns ...string) []string {
+	if !allServers {
+		return columns
+	}
+
+	return append([]string{"Server"}, columns...)
+}
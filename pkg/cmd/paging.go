@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+)
+
+// addPagingFlags adds the --limit, --all and --start-index flags of list commands
+func addPagingFlags(cmd *cobra.Command, defaultLimit int) {
+	cmd.Flags().IntP("limit", "l", defaultLimit, "Limit the number of results")
+	cmd.Flags().Bool("all", false, "Page through every result, ignoring --limit")
+	cmd.Flags().Int("start-index", 0, "Skip this many results")
+}
+
+// pageOptionsFromFlags builds pager options from the paging flags
+func pageOptionsFromFlags(cmd *cobra.Command) (client.PageOptions, error) {
+	// Get command flags
+	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	startIndex, _ := cmd.Flags().GetInt("start-index")
+
+	if startIndex < 0 {
+		return client.PageOptions{}, fmt.Errorf("--start-index must not be negative")
+	}
+
+	opts := client.PageOptions{StartIndex: startIndex, Prefetch: client.DefaultPrefetch}
+	if !all {
+		opts.Limit = max(limit, 0)
+	}
+
+	return opts, nil
+}
+
+// collect reads every item from a pager, along with the total the server reported
+func collect[T any](ctx context.Context, pager *client.Pager[T]) ([]T, int, error) {
+	items := make([]T, 0)
+	for item, err := range pager.All(ctx) {
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+
+	return items, pager.Total(), nil
+}
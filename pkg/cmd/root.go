@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,15 +18,18 @@ import (
 
 	"github.com/jfenske89/jellyfin-cli/pkg/client"
 	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
 )
 
 // Version is set during build
 var Version = "dev"
 
 var (
-	cfgFile string
-	config  models.Config
-	logger  *zap.SugaredLogger
+	cfgFile    string
+	serverName string
+	allServers bool
+	config     models.Config
+	logger     *zap.SugaredLogger
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -27,27 +38,67 @@ var rootCmd = &cobra.Command{
 	Short:   "Interact with Jellyfin from the command line",
 	Long:    `Jellyfin CLI is a command-line tool for interacting with a Jellyfin server.`,
 	Version: Version,
+
+	// Errors are printed by Execute, and usage only for invalid flags or arguments
+	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+		startDebugging()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	// Cancel the command context on Ctrl-C so long-running commands can exit cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+	finishDebugging()
+	if err != nil {
+		printError(os.Stderr, err)
+		stop()
+		os.Exit(exitCode(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	// Identify ourselves to the server with the build version
+	client.ClientVersion = Version
+
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/jellyfin-cli/config.yaml)")
-	rootCmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVar(&serverName, "server", "", "name of the configured server to use (default is default_server)")
+	rootCmd.PersistentFlags().StringVar(&serverName, "profile", "", "alias for --server")
+	rootCmd.PersistentFlags().BoolVar(&allServers, "all-servers", false, "run against every configured server and merge the results")
+	rootCmd.PersistentFlags().StringP("output", "o", string(output.FormatTable), "output format: table, text, json, ndjson, yaml, csv, tsv or template=<go-template>")
+	rootCmd.PersistentFlags().String("color", string(output.ColorAuto), "colorize table output: auto, always or never (auto honors NO_COLOR)")
+	rootCmd.PersistentFlags().Bool("json", false, "Output in JSON format (shorthand for --output json)")
+	rootCmd.PersistentFlags().StringSliceVar(&outputColumns, "columns", nil, "comma-separated fields to show, named as in JSON output (e.g. UserName,NowPlayingItem.Name)")
+	rootCmd.PersistentFlags().StringVar(&outputSortBy, "sort-by", "", "sort list output by a field, add -desc for descending (e.g. Date-desc)")
+	rootCmd.PersistentFlags().BoolVar(&outputNoHeaders, "no-headers", false, "omit the header line in table, csv and tsv output")
+	rootCmd.PersistentFlags().StringVar(&outputQuery, "query", "", "select values from the JSON output with a path expression (e.g. '.[].UserName')")
+	rootCmd.PersistentFlags().StringVar(&outputQuery, "jq", "", "alias for --query")
+	rootCmd.PersistentFlags().BoolVar(&traceRequests, "trace", false, "log every HTTP request and response, with credentials redacted")
+	rootCmd.PersistentFlags().StringVar(&harFile, "har", "", "save every HTTP request and response to a HAR file")
+
+	for _, alias := range []string{"profile", "jq"} {
+		if err := rootCmd.PersistentFlags().MarkHidden(alias); err != nil {
+			logger.Errorw("failed to hide flag", "error", err.Error())
+		}
+	}
 
 	// Bind flags to viper
-	err := viper.BindPFlag("output.json", rootCmd.PersistentFlags().Lookup("json"))
-	if err != nil {
+	if err := viper.BindPFlag("output.format", rootCmd.PersistentFlags().Lookup("output")); err != nil {
+		logger.Errorw("failed to bind flags", "error", err.Error())
+	}
+	if err := viper.BindPFlag("output.color", rootCmd.PersistentFlags().Lookup("color")); err != nil {
+		logger.Errorw("failed to bind flags", "error", err.Error())
+	}
+	if err := viper.BindPFlag("output.json", rootCmd.PersistentFlags().Lookup("json")); err != nil {
 		logger.Errorw("failed to bind flags", "error", err.Error())
 	}
 }
@@ -89,20 +140,127 @@ func initConfig() {
 			logger.Warnw("No config file found, using defaults")
 		}
 	} else {
-		// Set Jellyfin config from viper
-		config.Jellyfin.BaseURL = viper.GetString("api.base_url")
-		config.Jellyfin.Token = viper.GetString("api.token")
-		config.Jellyfin.SkipSSLVerify = viper.GetBool("api.insecure")
-
 		// Update logger level based on config
 		config.Logging.Level = viper.GetString("logging.level")
 		updateLogLevel(config.Logging.Level)
 
 		logger.Debugw("Using config file", "file", viper.ConfigFileUsed())
 	}
+
+	// Set Jellyfin config from viper
+	config.Jellyfin = readServerConfig(legacyServerKey)
+	config.DefaultServer = viper.GetString("default_server")
+	config.Servers = make(map[string]models.JellyfinConfig)
+	for name := range viper.GetStringMap("servers") {
+		config.Servers[name] = readServerConfig(serverKey(name))
+	}
+}
+
+// defaultRetries is how many times requests are retried when a server doesn't configure it
+const defaultRetries = 2
+
+// readServerConfig reads the connection settings stored under a config key
+func readServerConfig(key string) models.JellyfinConfig {
+	retries := defaultRetries
+	if viper.IsSet(key + ".retries") {
+		retries = viper.GetInt(key + ".retries")
+	}
+
+	return models.JellyfinConfig{
+		BaseURL:       viper.GetString(key + ".base_url"),
+		Token:         viper.GetString(key + ".token"),
+		UserID:        viper.GetString(key + ".user_id"),
+		DeviceID:      viper.GetString(key + ".device_id"),
+		SkipSSLVerify: viper.GetBool(key + ".insecure"),
+		Retries:       retries,
+		Timeout:       readDuration(key + ".timeout"),
+		RateLimit:     viper.GetFloat64(key + ".rate_limit"),
+		CACert:        readPath(key + ".ca_cert"),
+		ClientCert:    readPath(key + ".client_cert"),
+		ClientKey:     readPath(key + ".client_key"),
+		Proxy:         viper.GetString(key + ".proxy"),
+		Headers:       viper.GetStringMapString(key + ".headers"),
+		UnixSocket:    readPath(key + ".unix_socket"),
+	}
 }
 
-// getClient returns a new Jellyfin API client using the current configuration
-func getClient() client.Client {
-	return client.NewClient(config.Jellyfin, logger)
+// readPath reads a file path from the config, expanding a leading ~ to the home directory
+func readPath(key string) string {
+	path := viper.GetString(key)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+
+	return path
+}
+
+// readDuration reads a duration such as "45s" from the config, taking plain numbers as seconds
+func readDuration(key string) time.Duration {
+	if seconds, err := strconv.ParseFloat(viper.GetString(key), 64); err == nil {
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	return viper.GetDuration(key)
+}
+
+// getClient returns the Jellyfin API client for the active server
+func getClient() (client.Client, error) {
+	name, server, err := activeServer()
+	if err != nil {
+		return nil, err
+	}
+
+	return serverClient(name, server)
+}
+
+// clientFor returns a client for a server named in tagged results,
+// where an empty name stands for the active server
+func clientFor(server string) (client.Client, error) {
+	if server == "" {
+		return getClient()
+	}
+
+	config, ok := lookupServer(server)
+	if !ok {
+		return nil, fmt.Errorf("server %q is not configured", server)
+	}
+
+	return serverClient(server, config)
+}
+
+// serverClients holds the client made for each configured server, by name
+var serverClients = struct {
+	sync.Mutex
+	byName map[string]client.Client
+}{byName: make(map[string]client.Client)}
+
+// serverClient returns the client for a configured server, creating it on first use.
+// All requests a command makes to one server then share its rate limit.
+func serverClient(name string, server models.JellyfinConfig) (client.Client, error) {
+	serverClients.Lock()
+	defer serverClients.Unlock()
+
+	if c, ok := serverClients.byName[name]; ok {
+		return c, nil
+	}
+
+	c, err := newClient(name, server)
+	if err != nil {
+		return nil, err
+	}
+	serverClients.byName[name] = c
+
+	return c, nil
+}
+
+// newClient returns a new Jellyfin API client for the given server
+func newClient(name string, server models.JellyfinConfig) (client.Client, error) {
+	c, err := client.NewClient(server, logger.With("server", name), clientOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings for server %s: %w", name, err)
+	}
+
+	return c, nil
 }
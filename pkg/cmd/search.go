@@ -1,58 +1,85 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
 	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
 )
 
+// serverSearchHint is a search hint tagged with the server it was found on
+type serverSearchHint struct {
+	Server string `json:"Server,omitempty"`
+	models.SearchHint
+}
+
+// serverSearchResponse is a search response merged from one or more servers
+type serverSearchResponse struct {
+	SearchHints []serverSearchHint `json:"SearchHints"`
+	TotalHints  int                `json:"TotalRecordCount"`
+}
+
 // searchCmd represents the search command
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search for content on the Jellyfin server",
 	Long: `Search for content on the Jellyfin server using a text query.
 	
-You can filter results by type using the --type flag.`,
+You can filter results by type using the --type flag.
+Use --all to page through every result instead of stopping at --limit.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get client
-		client := getClient()
-
 		// Get command flags
 		itemType, _ := cmd.Flags().GetString("type")
-		limit, _ := cmd.Flags().GetInt("limit")
-		outputJSON, _ := cmd.Flags().GetBool("json")
+		opts, err := pageOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 
 		// Combine all args into a single search query
 		query := strings.Join(args, " ")
 
-		// Set up parameters
-		params := make(map[string]string)
+		// Set up the query
+		search := client.SearchQuery{SearchTerm: query}
 		if itemType != "" {
-			params["includeItemTypes"] = itemType
-		}
-		if limit > 0 {
-			params["limit"] = fmt.Sprintf("%d", limit)
+			search.IncludeItemTypes = []string{itemType}
 		}
 
 		// Search
-		results, err := client.Search(cmd.Context(), query, params)
+		responses, err := fanOut(cmd.Context(), func(ctx context.Context, c client.Client) (*models.SearchResponse, error) {
+			hints, total, err := collect(ctx, c.PageSearch(search, opts))
+			if err != nil {
+				return nil, err
+			}
+			return &models.SearchResponse{SearchHints: hints, TotalHints: total}, nil
+		})
 		if err != nil {
 			return fmt.Errorf("failed to search: %w", err)
 		}
 
-		// Output
-		if outputJSON {
-			outputSearchJSON(results)
-		} else {
-			outputSearchText(results, query)
+		results := &serverSearchResponse{SearchHints: make([]serverSearchHint, 0)}
+		for _, response := range responses {
+			results.TotalHints += response.Value.TotalHints
+			for _, hint := range response.Value.SearchHints {
+				results.SearchHints = append(results.SearchHints, serverSearchHint{Server: response.Server, SearchHint: hint})
+			}
 		}
 
-		return nil
+		// Output
+		return printResult(output.Result{
+			Data:    results,
+			Rows:    results.SearchHints,
+			Columns: withServerColumn("Type", "Name", "SeriesName", "ProductionYear", "ItemId"),
+			Text: func(w io.Writer) {
+				outputSearchText(w, results, query)
+			},
+		})
 	},
 }
 
@@ -61,19 +88,21 @@ func init() {
 
 	// Add local flags
 	searchCmd.Flags().StringP("type", "t", "", "Filter by item type (Movie, Series, Episode, etc.)")
-	searchCmd.Flags().IntP("limit", "l", 10, "Limit the number of results")
+	addPagingFlags(searchCmd, 10)
 }
 
 // outputSearchText outputs search results in human-readable format
-func outputSearchText(results *models.SearchResponse, query string) {
+func outputSearchText(w io.Writer, results *serverSearchResponse, query string) {
 	if results == nil || len(results.SearchHints) == 0 {
-		fmt.Printf("No results found for '%s'\n", query)
+		fmt.Fprintf(w, "No results found for '%s'\n", query)
 		return
 	}
 
-	fmt.Printf("Search Results for '%s' (Found: %d):\n", query, results.TotalHints)
+	fmt.Fprintf(w, "Search Results for '%s' (Found: %d):\n", query, results.TotalHints)
 
 	for i, hint := range results.SearchHints {
+		tag := serverTag(hint.Server)
+
 		// Format result based on type
 		switch hint.Type {
 		case "Movie":
@@ -81,35 +110,24 @@ func outputSearchText(results *models.SearchResponse, query string) {
 			if hint.ProductYear > 0 {
 				year = fmt.Sprintf(" (%d)", hint.ProductYear)
 			}
-			fmt.Printf(" %d. [Movie] %s%s\n", i+1, hint.Name, year)
+			fmt.Fprintf(w, " %d. %s[Movie] %s%s\n", i+1, tag, hint.Name, year)
 
 		case "Series":
 			year := ""
 			if hint.ProductYear > 0 {
 				year = fmt.Sprintf(" (%d)", hint.ProductYear)
 			}
-			fmt.Printf(" %d. [Series] %s%s\n", i+1, hint.Name, year)
+			fmt.Fprintf(w, " %d. %s[Series] %s%s\n", i+1, tag, hint.Name, year)
 
 		case "Episode":
 			episodeInfo := ""
 			if hint.SeasonNum > 0 && hint.EpisodeNum > 0 {
 				episodeInfo = fmt.Sprintf(" (S%02dE%02d)", hint.SeasonNum, hint.EpisodeNum)
 			}
-			fmt.Printf(" %d. [Episode] %s - %s%s\n", i+1, hint.SeriesName, hint.Name, episodeInfo)
+			fmt.Fprintf(w, " %d. %s[Episode] %s - %s%s\n", i+1, tag, hint.SeriesName, hint.Name, episodeInfo)
 
 		default:
-			fmt.Printf(" %d. [%s] %s\n", i+1, hint.Type, hint.Name)
+			fmt.Fprintf(w, " %d. %s[%s] %s\n", i+1, tag, hint.Type, hint.Name)
 		}
 	}
 }
-
-// outputSearchJSON outputs search results in JSON format
-func outputSearchJSON(results *models.SearchResponse) {
-	jsonBytes, err := json.MarshalIndent(results, "", "  ")
-	if err != nil {
-		logger.Errorw("Failed to marshal search results to JSON", "error", err)
-		return
-	}
-
-	fmt.Println(string(jsonBytes))
-}
@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// Health check statuses
+const (
+	healthOK   = "ok"
+	healthWarn = "warn"
+	healthFail = "fail"
+	healthSkip = "skip"
+)
+
+// serverSystemInfo is the system information of a server, tagged with its name
+type serverSystemInfo struct {
+	Server string `json:"Server,omitempty"`
+	models.SystemInfo
+
+	// Public is set when only the public information could be read
+	Public bool `json:"Public,omitempty"`
+}
+
+// serverPing is the outcome of pinging a server
+type serverPing struct {
+	Server  string        `json:"Server,omitempty"`
+	URL     string        `json:"URL"`
+	Name    string        `json:"Name,omitempty"`
+	Latency time.Duration `json:"Latency"`
+	Error   string        `json:"Error,omitempty"`
+
+	err error
+}
+
+// healthCheck is the outcome of a single health check against a server
+type healthCheck struct {
+	Server string `json:"Server,omitempty"`
+	Check  string `json:"Check"`
+	Status string `json:"Status"`
+	Detail string `json:"Detail"`
+
+	err error
+}
+
+// serverCmd represents the server command
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Check the Jellyfin server's status",
+	Long: `Show information about the Jellyfin server and check that it is up.
+
+To manage the servers in the config file, use the servers command instead.`,
+}
+
+// serverInfoCmd represents the server info command
+var serverInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show the server's version, operating system and paths",
+	Long: `Show the server's name, ID, version and operating system, whether it is waiting
+to restart or has an update available, and the paths it stores data in.
+
+Without an administrator token only the public information is shown.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get system info
+		results, err := fanOut(cmd.Context(), getServerInfo)
+		if err != nil {
+			return fmt.Errorf("failed to get server info: %w", err)
+		}
+
+		infos := make([]serverSystemInfo, 0, len(results))
+		for _, result := range results {
+			info := result.Value
+			info.Server = result.Server
+			infos = append(infos, info)
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data:    infos,
+			Columns: withServerColumn("ServerName", "Version", "OperatingSystem", "HasPendingRestart", "HasUpdateAvailable", "Id"),
+			Text: func(w io.Writer) {
+				outputServerInfoText(w, infos)
+			},
+		})
+	},
+}
+
+// serverPingCmd represents the server ping command
+var serverPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check that the server responds",
+	Long: `Check that the server responds and measure how long it takes.
+
+Exits with a non-zero status when any server fails to respond,
+or responds slower than --max-latency.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		maxLatency, _ := cmd.Flags().GetDuration("max-latency")
+
+		names, err := targetServers()
+		if err != nil {
+			return err
+		}
+
+		// Ping every server, keeping failures so they can be reported
+		pings := make([]serverPing, 0, len(names))
+		var failures []error
+		for _, name := range names {
+			ping := pingServer(cmd.Context(), name)
+			if ping.err == nil && maxLatency > 0 && ping.Latency > maxLatency {
+				ping.err = fmt.Errorf("latency %s exceeds %s", ping.Latency.Round(time.Millisecond), maxLatency)
+				ping.Error = ping.err.Error()
+			}
+			if ping.err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", name, ping.err))
+			}
+			if !allServers {
+				ping.Server = ""
+			}
+			pings = append(pings, ping)
+		}
+
+		// Output
+		if err := printResult(output.Result{
+			Data:    pings,
+			Columns: withServerColumn("URL", "Name", "Latency", "Error"),
+			Text: func(w io.Writer) {
+				outputPingText(w, pings)
+			},
+		}); err != nil {
+			return err
+		}
+
+		if len(failures) > 0 {
+			return fmt.Errorf("ping failed: %w", errors.Join(failures...))
+		}
+		return nil
+	},
+}
+
+// serverHealthCmd represents the server health command
+var serverHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Run health checks against the server",
+	Long: `Check that the server responds, reports its version, accepts the configured token
+and isn't shutting down, and warn about pending restarts and available updates.
+
+Exits with a non-zero status when a check fails, so it can be run from cron
+or a monitoring system. With --strict, warnings fail the health check too.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		maxLatency, _ := cmd.Flags().GetDuration("max-latency")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		names, err := targetServers()
+		if err != nil {
+			return err
+		}
+
+		checks := make([]healthCheck, 0)
+		var failures []error
+		for _, name := range names {
+			for _, check := range checkServerHealth(cmd.Context(), name, maxLatency) {
+				if check.Status == healthFail || (strict && check.Status == healthWarn) {
+					failure := check.err
+					if failure == nil {
+						failure = errors.New(check.Detail)
+					}
+					failures = append(failures, fmt.Errorf("%s: %s: %w", name, check.Check, failure))
+				}
+				if !allServers {
+					check.Server = ""
+				}
+				checks = append(checks, check)
+			}
+		}
+
+		// Output
+		if err := printResult(output.Result{
+			Data:    checks,
+			Columns: withServerColumn("Check", "Status", "Detail"),
+			Styles:  map[string]output.StyleFunc{"Status": healthStyle},
+			Text: func(w io.Writer) {
+				outputHealthText(w, checks)
+			},
+		}); err != nil {
+			return err
+		}
+
+		if len(failures) > 0 {
+			return fmt.Errorf("server is unhealthy: %w", errors.Join(failures...))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serverCmd)
+	serverCmd.AddCommand(serverInfoCmd)
+	serverCmd.AddCommand(serverPingCmd)
+	serverCmd.AddCommand(serverHealthCmd)
+
+	// Add local flags
+	serverPingCmd.Flags().Duration("max-latency", 0, "Fail when the server takes longer than this to respond, e.g. 500ms")
+	serverHealthCmd.Flags().Duration("max-latency", 0, "Fail when the server takes longer than this to respond, e.g. 500ms")
+	serverHealthCmd.Flags().Bool("strict", false, "Fail on warnings, such as a pending restart or an available update")
+}
+
+// getServerInfo reads a server's full system information,
+// falling back to the public information when the token isn't an administrator's
+func getServerInfo(ctx context.Context, c client.Client) (serverSystemInfo, error) {
+	info, err := c.GetSystemInfo(ctx)
+	if err == nil {
+		return serverSystemInfo{SystemInfo: *info}, nil
+	}
+	if !client.IsUnauthorized(err) && !client.IsForbidden(err) {
+		return serverSystemInfo{}, err
+	}
+
+	public, publicErr := c.GetPublicSystemInfo(ctx)
+	if publicErr != nil {
+		return serverSystemInfo{}, err
+	}
+
+	return serverSystemInfo{SystemInfo: models.SystemInfo{PublicSystemInfo: *public}, Public: true}, nil
+}
+
+// pingServer pings a configured server, measuring how long it takes to respond
+func pingServer(ctx context.Context, name string) serverPing {
+	server, _ := lookupServer(name)
+	ping := serverPing{Server: name, URL: server.BaseURL}
+
+	// A retried ping would count the failed attempt and the backoff as latency
+	c, err := serverClient(name, server)
+	if err == nil {
+		start := time.Now()
+		ping.Name, err = c.Ping(client.WithoutRetries(ctx))
+		ping.Latency = time.Since(start).Round(time.Microsecond)
+	}
+	if err != nil {
+		ping.err = err
+		ping.Error = err.Error()
+	}
+
+	return ping
+}
+
+// checkServerHealth runs the health checks against a configured server
+func checkServerHealth(ctx context.Context, name string, maxLatency time.Duration) []healthCheck {
+	// Report problems as they are instead of retrying past them
+	ctx = client.WithoutRetries(ctx)
+
+	checks := make([]healthCheck, 0, 5)
+	add := func(check, status, detail string, err error) {
+		checks = append(checks, healthCheck{Server: name, Check: check, Status: status, Detail: detail, err: err})
+	}
+
+	// The server must respond before anything else can be checked
+	ping := pingServer(ctx, name)
+	switch {
+	case ping.err != nil:
+		add("reachable", healthFail, ping.Error, ping.err)
+		return checks
+	case maxLatency > 0 && ping.Latency > maxLatency:
+		add("reachable", healthFail, fmt.Sprintf("responded in %s, over %s", ping.Latency.Round(time.Millisecond), maxLatency), nil)
+	default:
+		add("reachable", healthOK, fmt.Sprintf("responded in %s", ping.Latency.Round(time.Millisecond)), nil)
+	}
+
+	server, _ := lookupServer(name)
+	c, err := serverClient(name, server)
+	if err != nil {
+		add("version", healthFail, err.Error(), err)
+		return checks
+	}
+
+	public, err := c.GetPublicSystemInfo(ctx)
+	switch {
+	case err != nil:
+		add("version", healthFail, err.Error(), err)
+	case !public.StartupWizardCompleted:
+		add("version", healthWarn, fmt.Sprintf("%s %s, startup wizard not completed", public.ProductName, public.Version), nil)
+	default:
+		add("version", healthOK, fmt.Sprintf("%s %s", public.ProductName, public.Version), nil)
+	}
+
+	// The remaining checks need a token
+	if server.Token == "" {
+		add("authentication", healthSkip, "no token configured", nil)
+		return checks
+	}
+
+	info, err := c.GetSystemInfo(ctx)
+	switch {
+	case client.IsForbidden(err):
+		add("authentication", healthOK, "token accepted, but not an administrator's", nil)
+		add("restart", healthSkip, "needs an administrator token", nil)
+		add("updates", healthSkip, "needs an administrator token", nil)
+		return checks
+	case err != nil:
+		add("authentication", healthFail, err.Error(), err)
+		return checks
+	default:
+		add("authentication", healthOK, "token accepted", nil)
+	}
+
+	switch {
+	case info.IsShuttingDown:
+		add("restart", healthFail, "server is shutting down", nil)
+	case info.HasPendingRestart:
+		add("restart", healthWarn, "restart pending", nil)
+	default:
+		add("restart", healthOK, "no restart pending", nil)
+	}
+
+	if info.HasUpdateAvailable {
+		add("updates", healthWarn, "update available", nil)
+	} else {
+		add("updates", healthOK, "up to date", nil)
+	}
+
+	return checks
+}
+
+// healthStyle colors health check statuses
+func healthStyle(value string) output.Color {
+	switch value {
+	case healthOK:
+		return output.ColorGreen
+	case healthWarn:
+		return output.ColorYellow
+	case healthFail:
+		return output.ColorRed
+	case healthSkip:
+		return output.ColorGray
+	default:
+		return output.ColorNone
+	}
+}
+
+// outputServerInfoText outputs server information in human-readable format
+func outputServerInfoText(w io.Writer, infos []serverSystemInfo) {
+	field := func(label string, value any) {
+		fmt.Fprintf(w, "  %-18s %v\n", label+":", value)
+	}
+
+	for i, info := range infos {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "%s%s\n", serverTag(info.Server), info.ServerName)
+		field("ID", info.ID)
+		field("Version", fmt.Sprintf("%s %s", info.ProductName, info.Version))
+		if info.Public {
+			field("Operating System", info.OperatingSystem)
+			field("Address", info.LocalAddress)
+			fmt.Fprintln(w, "  (log in as an administrator for restart, update and path details)")
+			continue
+		}
+
+		system := info.OperatingSystemDisplayName
+		if system == "" {
+			system = info.OperatingSystem
+		}
+		if info.SystemArchitecture != "" {
+			system += " (" + info.SystemArchitecture + ")"
+		}
+		field("Operating System", system)
+		field("Address", info.LocalAddress)
+		field("Pending Restart", info.HasPendingRestart)
+		field("Update Available", info.HasUpdateAvailable)
+		if info.IsShuttingDown {
+			field("Shutting Down", true)
+		}
+
+		fmt.Fprintln(w, "Paths:")
+		field("Program Data", info.ProgramDataPath)
+		field("Cache", info.CachePath)
+		field("Logs", info.LogPath)
+		field("Metadata", info.InternalMetadataPath)
+		field("Transcoding", info.TranscodingTempPath)
+		field("Web", info.WebPath)
+	}
+}
+
+// outputPingText outputs ping results in human-readable format
+func outputPingText(w io.Writer, pings []serverPing) {
+	for _, ping := range pings {
+		if ping.Error != "" {
+			fmt.Fprintf(w, "%s%s: %s\n", serverTag(ping.Server), ping.URL, ping.Error)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s: %s responded in %s\n", serverTag(ping.Server), ping.URL, ping.Name, ping.Latency.Round(time.Millisecond))
+	}
+}
+
+// outputHealthText outputs health checks in human-readable format
+func outputHealthText(w io.Writer, checks []healthCheck) {
+	for _, check := range checks {
+		fmt.Fprintf(w, "%s[%-4s] %-15s %s\n", serverTag(check.Server), check.Status, check.Check, check.Detail)
+	}
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+const (
+	// legacyServerName is the name given to the single-server api section
+	legacyServerName = "default"
+
+	// legacyServerKey is the config key of the single-server api section
+	legacyServerKey = "api"
+)
+
+// serverNamePattern restricts server names to keys that survive viper's lowercasing
+var serverNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// serverEntry is a configured server as shown by the servers list command
+type serverEntry struct {
+	Name     string `json:"name"`
+	BaseURL  string `json:"base_url"`
+	Insecure bool   `json:"insecure"`
+	LoggedIn bool   `json:"logged_in"`
+	Active   bool   `json:"active"`
+}
+
+// serversCmd represents the servers command
+var serversCmd = &cobra.Command{
+	Use:   "servers",
+	Short: "Manage configured Jellyfin servers",
+	Long: `Manage the named Jellyfin servers in the config file.
+
+The active server is chosen with the global --server flag, falling back to default_server.`,
+}
+
+// serversListCmd represents the servers list command
+var serversListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured servers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		active, _ := activeServerName()
+
+		entries := make([]serverEntry, 0, len(config.Servers))
+		for _, name := range serverNames() {
+			server, _ := lookupServer(name)
+			entries = append(entries, serverEntry{
+				Name:     name,
+				BaseURL:  server.BaseURL,
+				Insecure: server.SkipSSLVerify,
+				LoggedIn: server.Token != "",
+				Active:   name == active,
+			})
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data:    entries,
+			Columns: []string{"name", "base_url", "insecure", "logged_in", "active"},
+			Text: func(w io.Writer) {
+				outputServersText(w, entries)
+			},
+		})
+	},
+}
+
+// serversAddCmd represents the servers add command
+var serversAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Add a server",
+	Long: `Add a named server to the config file.
+
+If the config file still uses the single api section, it is moved to a server named "default" first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if err := validateServerName(name); err != nil {
+			return err
+		}
+
+		// Get command flags
+		baseURL, _ := cmd.Flags().GetString("url")
+		token, _ := cmd.Flags().GetString("token")
+		insecure, _ := cmd.Flags().GetBool("insecure")
+		makeDefault, _ := cmd.Flags().GetBool("default")
+
+		err := updateConfig(func(settings configSettings) error {
+			if settings.Get(serverKey(name)) != nil {
+				return fmt.Errorf("server %q already exists", name)
+			}
+
+			// Move a legacy api section into a named server so it stays usable
+			if legacy := settings.Get(legacyServerKey); legacy != nil && settings.Get("servers") == nil {
+				if name == legacyServerName {
+					return fmt.Errorf("server %q already exists", name)
+				}
+				settings.Move(legacyServerKey, serverKey(legacyServerName))
+				if settings.Get("default_server") == nil {
+					settings.Set("default_server", legacyServerName)
+				}
+			}
+
+			key := serverKey(name)
+			settings.Set(key+".base_url", baseURL)
+			settings.Set(key+".insecure", insecure)
+			if token != "" {
+				settings.Set(key+".token", token)
+			}
+			if makeDefault || settings.Get("default_server") == nil {
+				settings.Set("default_server", name)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add server: %w", err)
+		}
+
+		fmt.Printf("Server %s added\n", name)
+		return nil
+	},
+}
+
+// serversRemoveCmd represents the servers remove command
+var serversRemoveCmd = &cobra.Command{
+	Use:     "remove [name]",
+	Aliases: []string{"rm"},
+	Short:   "Remove a server",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+
+		err := updateConfig(func(settings configSettings) error {
+			if settings.Get(serverKey(name)) == nil {
+				return fmt.Errorf("server %q not found", name)
+			}
+
+			settings.Delete(serverKey(name))
+			if settings.Get("default_server") == name {
+				settings.Delete("default_server")
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove server: %w", err)
+		}
+
+		fmt.Printf("Server %s removed\n", name)
+		return nil
+	},
+}
+
+// serversUseCmd represents the servers use command
+var serversUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the default server",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if _, ok := lookupServer(name); !ok {
+			return fmt.Errorf("server %q not found", name)
+		}
+
+		err := updateConfig(func(settings configSettings) error {
+			settings.Set("default_server", name)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set default server: %w", err)
+		}
+
+		fmt.Printf("Now using server %s\n", name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serversCmd)
+
+	serversCmd.AddCommand(serversListCmd)
+	serversCmd.AddCommand(serversAddCmd)
+	serversCmd.AddCommand(serversRemoveCmd)
+	serversCmd.AddCommand(serversUseCmd)
+
+	// Add local flags
+	serversAddCmd.Flags().String("url", "", "Base URL of the Jellyfin server")
+	serversAddCmd.Flags().String("token", "", "API token for authentication (or use login later)")
+	serversAddCmd.Flags().Bool("insecure", false, "Permit insecure SSL requests")
+	serversAddCmd.Flags().Bool("default", false, "Make this the default server")
+	_ = serversAddCmd.MarkFlagRequired("url")
+}
+
+// serverKey returns the config key of a named server
+func serverKey(name string) string {
+	return "servers." + name
+}
+
+// configKeyFor returns the config key holding a server's settings,
+// which is the legacy api section when no named servers are configured
+func configKeyFor(name string) string {
+	if len(config.Servers) == 0 && name == legacyServerName {
+		return legacyServerKey
+	}
+
+	return serverKey(name)
+}
+
+// serverNames returns the sorted names of all configured servers
+func serverNames() []string {
+	if len(config.Servers) == 0 {
+		return []string{legacyServerName}
+	}
+
+	names := make([]string, 0, len(config.Servers))
+	for name := range config.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// lookupServer returns the settings of a configured server
+func lookupServer(name string) (models.JellyfinConfig, bool) {
+	if len(config.Servers) == 0 {
+		return config.Jellyfin, name == legacyServerName
+	}
+
+	server, ok := config.Servers[name]
+	return server, ok
+}
+
+// activeServerName resolves the server selected by --server or default_server
+func activeServerName() (string, error) {
+	name := strings.ToLower(serverName)
+	if name == "" {
+		name = strings.ToLower(config.DefaultServer)
+	}
+
+	if name == "" {
+		names := serverNames()
+		if len(names) > 1 {
+			return "", errors.New("multiple servers are configured, choose one with --server or 'jellyfin-cli servers use'")
+		}
+		name = names[0]
+	}
+
+	if _, ok := lookupServer(name); !ok {
+		return "", fmt.Errorf("server %q is not configured", name)
+	}
+
+	return name, nil
+}
+
+// activeServer returns the name and settings of the active server
+func activeServer() (string, models.JellyfinConfig, error) {
+	name, err := activeServerName()
+	if err != nil {
+		return "", models.JellyfinConfig{}, err
+	}
+
+	server, _ := lookupServer(name)
+	return name, server, nil
+}
+
+// validateServerName checks that a server name can be stored as a config key
+func validateServerName(name string) error {
+	if !serverNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid server name %q: use letters, digits, '-' and '_'", name)
+	}
+
+	return nil
+}
+
+// outputServersText outputs servers in human-readable format
+func outputServersText(w io.Writer, entries []serverEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No servers configured")
+		return
+	}
+
+	fmt.Fprintln(w, "Servers:")
+	for _, entry := range entries {
+		marker := " "
+		if entry.Active {
+			marker = "*"
+		}
+
+		status := "not logged in"
+		if entry.LoggedIn {
+			status = "logged in"
+		}
+
+		fmt.Fprintf(w, " %s %s - %s (%s)\n", marker, entry.Name, entry.BaseURL, status)
+	}
+}
@@ -1,93 +1,358 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
 	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
 )
 
+// serverSession is a session tagged with the server it was found on
+type serverSession struct {
+	Server string `json:"Server,omitempty"`
+	Change string `json:"Change,omitempty"`
+	models.Session
+}
+
+// NowPlaying returns a display title for the item being played
+func (s serverSession) NowPlaying() string {
+	if s.NowPlayingItem == nil {
+		return ""
+	}
+
+	return itemTitle(s.NowPlayingItem)
+}
+
+// Progress returns the playback position and runtime, e.g. "12:03 / 45:00 (27%)"
+func (s serverSession) Progress() string {
+	if s.NowPlayingItem == nil || s.PlayState == nil {
+		return ""
+	}
+
+	position := s.PlayState.Position()
+	runtime := s.NowPlayingItem.Runtime()
+	if runtime <= 0 {
+		return formatDuration(position)
+	}
+
+	return fmt.Sprintf("%s / %s (%d%%)", formatDuration(position), formatDuration(runtime), int(100*position/runtime))
+}
+
 // sessionsCmd represents the sessions command
 var sessionsCmd = &cobra.Command{
 	Use:   "sessions",
 	Short: "List active sessions on the Jellyfin server",
 	Long: `List active or all sessions on the Jellyfin server.
 	
-By default, it shows all sessions. Use the --active flag to show only active sessions.`,
+By default, it shows all sessions. Use the --active flag to show only active sessions.
+Use --watch to refresh the list until interrupted, highlighting sessions that started or stopped.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get client
-		client := getClient()
-
 		// Get command flags
 		active, _ := cmd.Flags().GetBool("active")
-		outputJSON, _ := cmd.Flags().GetBool("json")
+		interval, _ := cmd.Flags().GetDuration("watch")
 
-		// Set up parameters
-		params := make(map[string]string)
-		if active {
-			params["activeWithinSeconds"] = "600"
+		// Keep refreshing in watch mode
+		if interval > 0 {
+			var previous []serverSession
+			first := true
+
+			return watch(cmd.Context(), cmd.CommandPath(), interval, func(ctx context.Context) (output.Result, error) {
+				sessions, err := listSessions(ctx, active)
+				if err != nil {
+					return output.Result{}, fmt.Errorf("failed to list sessions: %w", err)
+				}
+
+				marked := sessions
+				if !first {
+					marked = markSessionChanges(previous, sessions)
+				}
+				previous, first = sessions, false
+
+				return sessionsResult(marked, true), nil
+			})
 		}
 
 		// Get sessions
-		sessions, err := client.ListSessions(cmd.Context(), params)
+		sessions, err := listSessions(cmd.Context(), active)
 		if err != nil {
 			return fmt.Errorf("failed to list sessions: %w", err)
 		}
 
-		// Filter active sessions if needed
-		if active {
-			activeSessions := make([]models.Session, 0, len(sessions))
-			for _, s := range sessions {
-				if time.Since(s.LastActivityUTC) <= 10*time.Minute {
-					activeSessions = append(activeSessions, s)
-				}
-			}
-			sessions = activeSessions
+		// Output
+		return printResult(sessionsResult(sessions, false))
+	},
+}
+
+// sessionsShowCmd represents the sessions show command
+var sessionsShowCmd = &cobra.Command{
+	Use:   "show [session-id]",
+	Short: "Show details of a session",
+	Long: `Show details of a session, including the item being played, playback state and transcoding.
+
+The session ID may be abbreviated to any unique prefix.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get sessions
+		sessions, err := listSessions(cmd.Context(), false)
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
 		}
 
-		// Output
-		if outputJSON {
-			outputSessionsJSON(sessions)
-		} else {
-			outputSessionsText(sessions)
+		session, err := findSession(sessions, args[0])
+		if err != nil {
+			return err
 		}
 
-		return nil
+		// Output
+		return printResult(output.Result{
+			Data: session,
+			Text: func(w io.Writer) {
+				outputSessionDetailText(w, session)
+			},
+		})
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(sessionsCmd)
 
+	// Add subcommands
+	sessionsCmd.AddCommand(sessionsShowCmd)
+
 	// Add local flags
 	sessionsCmd.Flags().BoolP("active", "a", false, "Only show active sessions")
+	addWatchFlag(sessionsCmd)
+}
+
+// sessionsResult describes a list of sessions for output, with change markers when watching
+func sessionsResult(sessions []serverSession, watching bool) output.Result {
+	columns := withServerColumn("UserName", "DeviceName", "Client", "State", "NowPlaying", "Progress", "Id")
+	if watching {
+		columns = append([]string{"Change"}, columns...)
+	}
+
+	return output.Result{
+		Data:    sessions,
+		Columns: columns,
+		Styles: map[string]output.StyleFunc{
+			"State":  output.PlaybackStateStyle,
+			"Change": output.ChangeStyle,
+		},
+		Text: func(w io.Writer) {
+			outputSessionsText(w, sessions)
+		},
+	}
+}
+
+// markSessionChanges marks sessions that are new since the previous refresh,
+// and appends sessions that have disappeared marked as stopped
+func markSessionChanges(previous, current []serverSession) []serverSession {
+	key := func(s serverSession) string {
+		return s.Server + "/" + s.ID
+	}
+
+	seen := make(map[string]bool, len(previous))
+	for _, session := range previous {
+		seen[key(session)] = true
+	}
+
+	marked := make([]serverSession, 0, len(current))
+	present := make(map[string]bool, len(current))
+	for _, session := range current {
+		present[key(session)] = true
+		if !seen[key(session)] {
+			session.Change = "new"
+		}
+		marked = append(marked, session)
+	}
+
+	for _, session := range previous {
+		if !present[key(session)] {
+			session.Change = "stopped"
+			marked = append(marked, session)
+		}
+	}
+
+	return marked
+}
+
+// listSessions fetches sessions from the target servers, optionally only active ones
+func listSessions(ctx context.Context, active bool) ([]serverSession, error) {
+	// Set up the query
+	var query client.SessionQuery
+	if active {
+		query.ActiveWithinSeconds = int(models.ActiveSessionWindow.Seconds())
+	}
+
+	results, err := fanOut(ctx, func(ctx context.Context, c client.Client) ([]models.Session, error) {
+		return c.ListSessions(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]serverSession, 0)
+	for _, result := range results {
+		for _, s := range result.Value {
+			// Filter active sessions if needed
+			if active && time.Since(s.LastActivityUTC) > models.ActiveSessionWindow {
+				continue
+			}
+			sessions = append(sessions, serverSession{Server: result.Server, Session: s})
+		}
+	}
+
+	return sessions, nil
+}
+
+// findSession finds a session by its ID or a unique prefix of it
+func findSession(sessions []serverSession, id string) (serverSession, error) {
+	var matches []serverSession
+	for _, session := range sessions {
+		if strings.EqualFold(session.ID, id) {
+			return session, nil
+		}
+		if strings.HasPrefix(strings.ToLower(session.ID), strings.ToLower(id)) {
+			matches = append(matches, session)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return serverSession{}, fmt.Errorf("session %q not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return serverSession{}, fmt.Errorf("session ID %q is ambiguous, it matches %d sessions", id, len(matches))
+	}
+}
+
+// itemTitle formats an item name, adding the series and episode number for episodes
+func itemTitle(item *models.NowPlayingItem) string {
+	switch {
+	case item.SeriesName != "" && item.SeasonNum > 0 && item.EpisodeNum > 0:
+		return fmt.Sprintf("%s - S%02dE%02d - %s", item.SeriesName, item.SeasonNum, item.EpisodeNum, item.Name)
+	case item.SeriesName != "":
+		return fmt.Sprintf("%s - %s", item.SeriesName, item.Name)
+	case item.ProductionYear > 0:
+		return fmt.Sprintf("%s (%d)", item.Name, item.ProductionYear)
+	default:
+		return item.Name
+	}
+}
+
+// formatDuration formats a duration as H:MM:SS, or M:SS when under an hour
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
 }
 
 // outputSessionsText outputs sessions in human-readable format
-func outputSessionsText(sessions []models.Session) {
+func outputSessionsText(w io.Writer, sessions []serverSession) {
 	if len(sessions) == 0 {
-		fmt.Println("No sessions found")
+		fmt.Fprintln(w, "No sessions found")
 		return
 	}
 
-	fmt.Println("Sessions:")
+	fmt.Fprintln(w, "Sessions:")
 	for _, session := range sessions {
 		duration := humanize.RelTime(time.Now(), session.LastActivityUTC, "", "ago")
-		fmt.Printf(" - %s on %s (%s)\n", session.UserName, session.DeviceName, duration)
+		change := ""
+		if session.Change != "" {
+			change = fmt.Sprintf(" [%s]", session.Change)
+		}
+		fmt.Fprintf(w, " - %s%s on %s (%s)%s\n", serverTag(session.Server), session.UserName, session.DeviceName, duration, change)
+
+		if session.NowPlayingItem != nil {
+			fmt.Fprintf(w, "   %s: %s [%s]\n", session.State(), session.NowPlaying(), session.Progress())
+		}
 	}
 }
 
-// outputSessionsJSON outputs sessions in JSON format
-func outputSessionsJSON(sessions []models.Session) {
-	jsonBytes, err := json.MarshalIndent(sessions, "", "  ")
-	if err != nil {
-		logger.Errorw("Failed to marshal sessions to JSON", "error", err)
-		return
+// outputSessionDetailText outputs a single session in human-readable format
+func outputSessionDetailText(w io.Writer, session serverSession) {
+	field := func(label string, value any) {
+		fmt.Fprintf(w, "  %-18s %v\n", label+":", value)
+	}
+
+	fmt.Fprintf(w, "Session %s\n", session.ID)
+	if session.Server != "" {
+		field("Server", session.Server)
+	}
+	field("User", session.UserName)
+	field("Client", strings.TrimSpace(session.ClientName+" "+session.ApplicationVersion))
+	field("Device", fmt.Sprintf("%s (%s)", session.DeviceName, session.DeviceID))
+	if session.RemoteEndPoint != "" {
+		field("Remote Endpoint", session.RemoteEndPoint)
+	}
+	field("Last Activity", humanize.RelTime(time.Now(), session.LastActivityUTC, "", "ago"))
+	field("State", session.State())
+
+	if item := session.NowPlayingItem; item != nil {
+		fmt.Fprintln(w, "Now Playing:")
+		field("Title", session.NowPlaying())
+		field("Type", item.Type)
+		field("Item ID", item.ID)
+		if progress := session.Progress(); progress != "" {
+			field("Progress", progress)
+		}
+	}
+
+	if state := session.PlayState; state != nil && session.NowPlayingItem != nil {
+		fmt.Fprintln(w, "Play State:")
+		field("Play Method", state.PlayMethod)
+		field("Paused", state.IsPaused)
+		field("Muted", state.IsMuted)
+		field("Volume", state.VolumeLevel)
+	}
+
+	if info := session.TranscodingInfo; info != nil {
+		fmt.Fprintln(w, "Transcoding:")
+		field("Container", info.Container)
+		field("Video", transcodeStream(info.VideoCodec, info.IsVideoDirect))
+		field("Audio", transcodeStream(info.AudioCodec, info.IsAudioDirect))
+		if info.Width > 0 && info.Height > 0 {
+			field("Resolution", fmt.Sprintf("%dx%d", info.Width, info.Height))
+		}
+		if info.Bitrate > 0 {
+			field("Bitrate", humanize.SI(float64(info.Bitrate), "bps"))
+		}
+		if info.Framerate > 0 {
+			field("Framerate", fmt.Sprintf("%.2f fps", info.Framerate))
+		}
+		if info.HardwareAccelerationType != "" {
+			field("Hardware Accel.", info.HardwareAccelerationType)
+		}
+		if len(info.TranscodeReasons) > 0 {
+			field("Reasons", strings.Join(info.TranscodeReasons, ", "))
+		}
+		if info.CompletionPercentage > 0 {
+			field("Completion", fmt.Sprintf("%.1f%%", info.CompletionPercentage))
+		}
+	}
+}
+
+// transcodeStream describes whether a stream is passed through or transcoded
+func transcodeStream(codec string, direct bool) string {
+	if direct {
+		return fmt.Sprintf("%s (direct)", codec)
 	}
 
-	fmt.Println(string(jsonBytes))
+	return fmt.Sprintf("%s (transcoding)", codec)
 }
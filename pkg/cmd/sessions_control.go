@@ -0,0 +1,438 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// playstateActions maps control actions to playback commands
+var playstateActions = map[string]models.PlaystateCommand{
+	"pause":    models.PlaystatePause,
+	"unpause":  models.PlaystateUnpause,
+	"resume":   models.PlaystateUnpause,
+	"toggle":   models.PlaystatePlayPause,
+	"stop":     models.PlaystateStop,
+	"next":     models.PlaystateNextTrack,
+	"previous": models.PlaystatePreviousTrack,
+	"prev":     models.PlaystatePreviousTrack,
+}
+
+// generalActions maps control actions to general commands that take no arguments
+var generalActions = map[string]string{
+	"mute":   "Mute",
+	"unmute": "Unmute",
+}
+
+// playModes maps the --mode flag of sessions play to play commands
+var playModes = map[string]models.PlayCommand{
+	"now":  models.PlayNow,
+	"next": models.PlayNext,
+	"last": models.PlayLast,
+}
+
+// sessionsControlCmd represents the sessions control command
+var sessionsControlCmd = &cobra.Command{
+	Use:   "control [session-id] [command] [argument]",
+	Short: "Control playback of a session",
+	Long: `Control playback of a session that supports remote control.
+
+Commands:
+  pause, unpause, toggle, stop, next, previous
+  seek <time>      jump to a position such as 1:23:45, 90s, or +30s / -1m relative to now
+  volume <0-100>   set the volume
+  mute, unmute
+
+The session ID may be abbreviated to any unique prefix. Flags go before the session ID,
+as everything after it is read as the command and its argument.`,
+	Example: `  jellyfin-cli sessions control 3f2a pause
+  jellyfin-cli sessions control --dry-run 3f2a seek -1m`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		action := strings.ToLower(args[1])
+		argument := ""
+		if len(args) > 2 {
+			argument = args[2]
+		}
+
+		session, c, err := resolveControllableSession(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := sendControlAction(cmd.Context(), c, session, action, argument); err != nil {
+			return err
+		}
+
+		reportf("Sent %s to %s on %s\n", action, session.UserName, session.DeviceName)
+		return nil
+	},
+}
+
+// sessionsPlayCmd represents the sessions play command
+var sessionsPlayCmd = &cobra.Command{
+	Use:   "play [session-id] [item-id...]",
+	Short: "Play items on a session",
+	Long: `Play one or more items on a session that supports remote control.
+
+Use --mode to queue the items after the current item or at the end of the queue instead of playing them now.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		mode, _ := cmd.Flags().GetString("mode")
+		start, _ := cmd.Flags().GetString("start")
+
+		playCommand, ok := playModes[strings.ToLower(mode)]
+		if !ok {
+			return fmt.Errorf("invalid play mode %q: use now, next or last", mode)
+		}
+
+		var opts client.PlayOptions
+		if start != "" {
+			position, err := parsePosition(start, 0)
+			if err != nil {
+				return err
+			}
+			opts.StartPositionTicks = models.DurationToTicks(position)
+		}
+
+		session, c, err := resolveControllableSession(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := c.Play(cmd.Context(), session.ID, args[1:], playCommand, opts); err != nil {
+			return fmt.Errorf("failed to play items: %w", err)
+		}
+
+		reportf("Sent %d item(s) to %s on %s\n", len(args)-1, session.UserName, session.DeviceName)
+		return nil
+	},
+}
+
+// messageDelivery records whether a message reached a session
+type messageDelivery struct {
+	Server     string `json:"Server,omitempty"`
+	SessionID  string `json:"SessionId"`
+	UserName   string `json:"UserName"`
+	DeviceName string `json:"DeviceName"`
+	Delivered  bool   `json:"Delivered"`
+	DryRun     bool   `json:"DryRun,omitempty"`
+	Error      string `json:"Error,omitempty"`
+}
+
+// sessionsMessageCmd represents the sessions message command
+var sessionsMessageCmd = &cobra.Command{
+	Use:   "message [session-id]",
+	Short: "Display a message on a session's client",
+	Long: `Display a message on a session's client, for example to warn viewers before maintenance.
+
+Use --all instead of a session ID to message every session (or every active session with --active).
+With --all-servers, sessions on every configured server are included.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		if all {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		all, _ := cmd.Flags().GetBool("all")
+		active, _ := cmd.Flags().GetBool("active")
+		header, _ := cmd.Flags().GetString("header")
+		text, _ := cmd.Flags().GetString("text")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		message := models.MessageCommand{
+			Header:    header,
+			Text:      text,
+			TimeoutMs: timeout.Milliseconds(),
+		}
+
+		// Find the recipients
+		sessions, err := listSessions(cmd.Context(), active)
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+		if !all {
+			session, err := findSession(sessions, args[0])
+			if err != nil {
+				return err
+			}
+			sessions = []serverSession{session}
+		}
+
+		// Send the message to each session, carrying on past failures
+		deliveries := make([]messageDelivery, 0, len(sessions))
+		failed := 0
+		for _, session := range sessions {
+			delivery := messageDelivery{
+				Server:     session.Server,
+				SessionID:  session.ID,
+				UserName:   session.UserName,
+				DeviceName: session.DeviceName,
+				DryRun:     dryRun,
+			}
+
+			c, err := clientFor(session.Server)
+			if err == nil {
+				err = c.SendMessage(cmd.Context(), session.ID, message)
+			}
+			if err != nil {
+				delivery.Error = err.Error()
+				failed++
+			} else {
+				// Under --dry-run the message was only printed, not delivered
+				delivery.Delivered = !dryRun
+			}
+
+			deliveries = append(deliveries, delivery)
+		}
+
+		// Output
+		columns := []string{"SessionId", "UserName", "DeviceName", "Delivered", "Error"}
+		if dryRun {
+			columns = []string{"SessionId", "UserName", "DeviceName", "Delivered", "DryRun", "Error"}
+		}
+		err = printResult(output.Result{
+			Data:    deliveries,
+			Columns: withServerColumn(columns...),
+			Text: func(w io.Writer) {
+				outputDeliveriesText(w, deliveries)
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if failed > 0 && failed == len(deliveries) {
+			return fmt.Errorf("message could not be delivered to any session")
+		}
+
+		return nil
+	},
+}
+
+// sessionsLogoutCmd represents the sessions logout command
+var sessionsLogoutCmd = &cobra.Command{
+	Use:   "logout [session-id]",
+	Short: "Force a session to log out",
+	Long: `Force a session to log out by removing its device, which revokes the device's access token.
+
+The client will have to sign in again to reconnect.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, err := listSessions(cmd.Context(), false)
+		if err != nil {
+			return fmt.Errorf("failed to list sessions: %w", err)
+		}
+
+		session, err := findSession(sessions, args[0])
+		if err != nil {
+			return err
+		}
+		if session.DeviceID == "" {
+			return fmt.Errorf("session %s has no device to log out", session.ID)
+		}
+
+		c, err := clientFor(session.Server)
+		if err != nil {
+			return err
+		}
+
+		if err := c.DeleteDevice(cmd.Context(), session.DeviceID); err != nil {
+			return fmt.Errorf("failed to log out session: %w", err)
+		}
+
+		reportf("Logged out %s on %s\n", session.UserName, session.DeviceName)
+		return nil
+	},
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsControlCmd)
+	sessionsCmd.AddCommand(sessionsPlayCmd)
+	sessionsCmd.AddCommand(sessionsMessageCmd)
+	sessionsCmd.AddCommand(sessionsLogoutCmd)
+
+	// Keep relative seeks such as -1m from being read as flags
+	sessionsControlCmd.Flags().SetInterspersed(false)
+
+	// Add local flags
+	sessionsPlayCmd.Flags().String("mode", "now", "When to play the items: now, next or last")
+	sessionsPlayCmd.Flags().String("start", "", "Position to start playing from, e.g. 12:30")
+
+	sessionsMessageCmd.Flags().Bool("all", false, "Send the message to every session")
+	sessionsMessageCmd.Flags().BoolP("active", "a", false, "With --all, only message active sessions")
+	sessionsMessageCmd.Flags().String("header", "", "Message header")
+	sessionsMessageCmd.Flags().String("text", "", "Message text")
+	sessionsMessageCmd.Flags().Duration("timeout", 0, "How long the message is shown, e.g. 10s (default is until dismissed)")
+	_ = sessionsMessageCmd.MarkFlagRequired("text")
+
+	for _, cmd := range []*cobra.Command{sessionsControlCmd, sessionsPlayCmd, sessionsMessageCmd, sessionsLogoutCmd} {
+		addDryRunFlag(cmd)
+	}
+}
+
+// resolveControllableSession finds a session that accepts remote control and a client for its server
+func resolveControllableSession(ctx context.Context, id string) (serverSession, client.Client, error) {
+	sessions, err := listSessions(ctx, false)
+	if err != nil {
+		return serverSession{}, nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	session, err := findSession(sessions, id)
+	if err != nil {
+		return serverSession{}, nil, err
+	}
+
+	if !session.SupportsRemoteControl {
+		return serverSession{}, nil, fmt.Errorf("session %s (%s on %s) does not support remote control", session.ID, session.UserName, session.DeviceName)
+	}
+
+	c, err := clientFor(session.Server)
+	if err != nil {
+		return serverSession{}, nil, err
+	}
+
+	return session, c, nil
+}
+
+// sendControlAction sends a control action with its optional argument to a session
+func sendControlAction(ctx context.Context, c client.Client, session serverSession, action, argument string) error {
+	requireArgument := func(usage string) error {
+		if argument == "" {
+			return fmt.Errorf("%s requires an argument: %s", action, usage)
+		}
+		return nil
+	}
+
+	switch action {
+	case "seek":
+		if err := requireArgument("seek <time>"); err != nil {
+			return err
+		}
+
+		var current time.Duration
+		if session.PlayState != nil {
+			current = session.PlayState.Position()
+		}
+
+		position, err := parsePosition(argument, current)
+		if err != nil {
+			return err
+		}
+
+		opts := client.PlaystateOptions{SeekPositionTicks: models.DurationToTicks(position)}
+		return c.SendPlaystateCommand(ctx, session.ID, models.PlaystateSeek, opts)
+
+	case "volume":
+		if err := requireArgument("volume <0-100>"); err != nil {
+			return err
+		}
+
+		volume, err := strconv.Atoi(argument)
+		if err != nil || volume < 0 || volume > 100 {
+			return fmt.Errorf("invalid volume %q: use a number from 0 to 100", argument)
+		}
+
+		return c.SendGeneralCommand(ctx, session.ID, models.GeneralCommand{
+			Name:      "SetVolume",
+			Arguments: map[string]string{"Volume": strconv.Itoa(volume)},
+		})
+	}
+
+	if strings.HasPrefix(argument, "--") {
+		return fmt.Errorf("%s does not take an argument, flags such as %s go before the session ID", action, argument)
+	}
+	if argument != "" {
+		return fmt.Errorf("%s does not take an argument", action)
+	}
+
+	if command, ok := playstateActions[action]; ok {
+		return c.SendPlaystateCommand(ctx, session.ID, command, client.PlaystateOptions{})
+	}
+	if name, ok := generalActions[action]; ok {
+		return c.SendGeneralCommand(ctx, session.ID, models.GeneralCommand{Name: name})
+	}
+
+	return fmt.Errorf("unknown control command %q", action)
+}
+
+// parsePosition parses a playback position such as 1:23:45, 12:30, 90, 90s or 1m30s.
+// A leading + or - makes the position relative to current.
+func parsePosition(value string, current time.Duration) (time.Duration, error) {
+	sign := 0
+	switch {
+	case strings.HasPrefix(value, "+"):
+		sign = 1
+		value = value[1:]
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	}
+
+	offset, err := parseClock(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid position %q: use a time such as 1:23:45, 12:30 or 90s", value)
+	}
+
+	position := offset
+	if sign != 0 {
+		position = current + time.Duration(sign)*offset
+	}
+
+	return max(position, 0), nil
+}
+
+// parseClock parses H:MM:SS, M:SS, plain seconds, or a Go duration
+func parseClock(value string) (time.Duration, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("too many parts")
+	}
+
+	var total time.Duration
+	for _, part := range parts {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number %q", part)
+		}
+		total = total*60 + time.Duration(n*float64(time.Second))
+	}
+
+	return total, nil
+}
+
+// outputDeliveriesText outputs message deliveries in human-readable format
+func outputDeliveriesText(w io.Writer, deliveries []messageDelivery) {
+	if len(deliveries) == 0 {
+		fmt.Fprintln(w, "No sessions to message")
+		return
+	}
+
+	for _, delivery := range deliveries {
+		switch {
+		case delivery.Delivered:
+			fmt.Fprintf(w, " - %sMessage sent to %s on %s\n", serverTag(delivery.Server), delivery.UserName, delivery.DeviceName)
+		case delivery.DryRun && delivery.Error == "":
+			fmt.Fprintf(w, " - %sMessage would be sent to %s on %s\n", serverTag(delivery.Server), delivery.UserName, delivery.DeviceName)
+		default:
+			fmt.Fprintf(w, " - %sFailed to message %s on %s: %s\n", serverTag(delivery.Server), delivery.UserName, delivery.DeviceName, delivery.Error)
+		}
+	}
+}
@@ -0,0 +1,623 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// defaultTaskPollInterval is how often a running task is checked when waiting for it
+const defaultTaskPollInterval = 2 * time.Second
+
+// progressBarWidth is the number of characters in the bar showing a running task's progress
+const progressBarWidth = 30
+
+// errTaskFailed is returned when a task that was waited for doesn't complete successfully
+var errTaskFailed = errors.New("task did not complete")
+
+// serverTask is a scheduled task tagged with the server it runs on
+type serverTask struct {
+	Server string `json:"Server,omitempty"`
+	models.TaskInfo
+}
+
+// LastResult returns the status of the task's last run
+func (t serverTask) LastResult() string {
+	if t.LastExecutionResult == nil {
+		return ""
+	}
+
+	return t.LastExecutionResult.Status
+}
+
+// LastRun returns when the task's last run ended, relative to now
+func (t serverTask) LastRun() string {
+	if t.LastExecutionResult == nil || t.LastExecutionResult.EndTimeUTC.IsZero() {
+		return "never"
+	}
+
+	return humanize.RelTime(time.Now(), t.LastExecutionResult.EndTimeUTC, "", "ago")
+}
+
+// LastDuration returns how long the task's last run took
+func (t serverTask) LastDuration() string {
+	if t.LastExecutionResult == nil {
+		return ""
+	}
+
+	return formatDuration(t.LastExecutionResult.Duration())
+}
+
+// tasksCmd represents the tasks command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Manage scheduled tasks",
+	Long: `List, run and stop the server's scheduled tasks, such as scanning the media library,
+cleaning the cache or extracting chapter images, and change when they run.
+
+Tasks can be named by key (e.g. RefreshLibrary), name (e.g. "Scan Media Library") or ID.`,
+}
+
+// tasksListCmd represents the tasks list command
+var tasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled tasks",
+	Long: `List the scheduled tasks with their state, progress and the result of their last run.
+
+Hidden tasks are only listed with --hidden.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		hidden, _ := cmd.Flags().GetBool("hidden")
+		category, _ := cmd.Flags().GetString("category")
+
+		var query client.TaskQuery
+		if !hidden {
+			query.IsHidden = &hidden
+		}
+
+		// Get tasks
+		results, err := fanOut(cmd.Context(), func(ctx context.Context, c client.Client) ([]models.TaskInfo, error) {
+			return c.ListScheduledTasks(ctx, query)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list scheduled tasks: %w", err)
+		}
+
+		tasks := make([]serverTask, 0)
+		for _, result := range results {
+			for _, task := range result.Value {
+				if category != "" && !strings.EqualFold(task.Category, category) {
+					continue
+				}
+				tasks = append(tasks, serverTask{Server: result.Server, TaskInfo: task})
+			}
+		}
+		slices.SortStableFunc(tasks, func(a, b serverTask) int {
+			return strings.Compare(a.Category+"\x00"+a.Name, b.Category+"\x00"+b.Name)
+		})
+
+		// Output
+		return printResult(output.Result{
+			Data:    tasks,
+			Columns: withServerColumn("Name", "Category", "State", "Progress", "LastResult", "LastRun", "LastDuration", "Key"),
+			Styles:  map[string]output.StyleFunc{"LastResult": taskStatusStyle},
+			Text: func(w io.Writer) {
+				outputTasksText(w, tasks)
+			},
+		})
+	},
+}
+
+// tasksShowCmd represents the tasks show command
+var tasksShowCmd = &cobra.Command{
+	Use:   "show [task]",
+	Short: "Show details of a scheduled task",
+	Long:  `Show a scheduled task's description, state, progress, triggers and the result of its last run.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		task, err := resolveTask(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data: task,
+			Text: func(w io.Writer) {
+				outputTaskDetailText(w, task)
+			},
+		})
+	},
+}
+
+// tasksRunCmd represents the tasks run command
+var tasksRunCmd = &cobra.Command{
+	Use:   "run [task]",
+	Short: "Run a scheduled task now",
+	Long: `Start a scheduled task now.
+
+With --wait, the command shows the task's progress and blocks until it finishes,
+exiting with a non-zero status when the task fails or is cancelled. Interrupting
+the command or reaching --timeout stops waiting, but leaves the task running.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		task, err := resolveTask(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Remember the previous run, so it isn't mistaken for this one
+		var since time.Time
+		if task.LastExecutionResult != nil {
+			since = task.LastExecutionResult.EndTimeUTC
+		}
+
+		if err := c.StartScheduledTask(cmd.Context(), task.ID); err != nil {
+			return fmt.Errorf("failed to run %s: %w", task.Name, err)
+		}
+
+		if !wait || dryRun {
+			reportf("Started %s\n", task.Name)
+			return nil
+		}
+
+		ctx, cancel := withWaitTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		return waitForTaskResult(ctx, c, task, since, interval)
+	},
+}
+
+// tasksStopCmd represents the tasks stop command
+var tasksStopCmd = &cobra.Command{
+	Use:   "stop [task]",
+	Short: "Stop a running scheduled task",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		task, err := resolveTask(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+		if task.State == models.TaskStateIdle {
+			return fmt.Errorf("%s is not running", task.Name)
+		}
+
+		if err := c.StopScheduledTask(cmd.Context(), task.ID); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", task.Name, err)
+		}
+
+		reportf("Stopping %s\n", task.Name)
+		return nil
+	},
+}
+
+// tasksTriggersCmd represents the tasks triggers command
+var tasksTriggersCmd = &cobra.Command{
+	Use:   "triggers [task]",
+	Short: "Show or change when a scheduled task runs",
+	Long: `Show when a scheduled task runs.
+
+Any of --daily, --weekly, --interval or --startup replaces the task's triggers
+with the ones given, or adds them to the existing triggers with --add.
+Use --clear to remove every trigger, so the task only runs when started manually.`,
+	Example: `  jellyfin-cli tasks triggers RefreshLibrary
+  jellyfin-cli tasks triggers RefreshLibrary --daily 03:00 --max-runtime 2h
+  jellyfin-cli tasks triggers "Clean Cache" --weekly sunday@04:30 --add
+  jellyfin-cli tasks triggers RefreshChapterImages --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		daily, _ := cmd.Flags().GetStringSlice("daily")
+		weekly, _ := cmd.Flags().GetStringSlice("weekly")
+		intervals, _ := cmd.Flags().GetDurationSlice("interval")
+		startup, _ := cmd.Flags().GetBool("startup")
+		maxRuntime, _ := cmd.Flags().GetDuration("max-runtime")
+		add, _ := cmd.Flags().GetBool("add")
+		clear, _ := cmd.Flags().GetBool("clear")
+
+		triggers, err := parseTriggers(daily, weekly, intervals, startup, maxRuntime)
+		if err != nil {
+			return err
+		}
+		if clear && len(triggers) > 0 {
+			return errors.New("--clear can't be combined with new triggers")
+		}
+		if add && len(triggers) == 0 {
+			return errors.New("--add needs a trigger to add")
+		}
+
+		// Get client
+		c, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		task, err := resolveTask(cmd.Context(), c, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Update the triggers when asked to
+		if clear || len(triggers) > 0 {
+			if add {
+				triggers = append(slices.Clone(task.Triggers), triggers...)
+			}
+
+			if err := c.UpdateTaskTriggers(cmd.Context(), task.ID, triggers); err != nil {
+				return fmt.Errorf("failed to update triggers of %s: %w", task.Name, err)
+			}
+			if !dryRun {
+				task.Triggers = triggers
+			}
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data:    task.Triggers,
+			Columns: []string{"Type", "Schedule", "MaxRuntime"},
+			Text: func(w io.Writer) {
+				outputTriggersText(w, task)
+			},
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tasksCmd)
+	tasksCmd.AddCommand(tasksListCmd)
+	tasksCmd.AddCommand(tasksShowCmd)
+	tasksCmd.AddCommand(tasksRunCmd)
+	tasksCmd.AddCommand(tasksStopCmd)
+	tasksCmd.AddCommand(tasksTriggersCmd)
+
+	// Add local flags
+	tasksListCmd.Flags().Bool("hidden", false, "Include hidden tasks")
+	tasksListCmd.Flags().String("category", "", "Only list tasks in a category, e.g. Library")
+
+	tasksRunCmd.Flags().Bool("wait", false, "Wait for the task to finish, failing if it doesn't complete")
+	tasksRunCmd.Flags().Duration("timeout", 0, "With --wait, stop waiting after this long, e.g. 30m (default is no limit)")
+	tasksRunCmd.Flags().Duration("interval", defaultTaskPollInterval, "With --wait, how often to check the task's progress")
+
+	tasksTriggersCmd.Flags().StringSlice("daily", nil, "Run every day at a time, e.g. 03:00")
+	tasksTriggersCmd.Flags().StringSlice("weekly", nil, "Run every week on a day at a time, e.g. sunday@03:00")
+	tasksTriggersCmd.Flags().DurationSlice("interval", nil, "Run repeatedly with this much time in between, e.g. 12h")
+	tasksTriggersCmd.Flags().Bool("startup", false, "Run when the server starts")
+	tasksTriggersCmd.Flags().Duration("max-runtime", 0, "Stop runs started by the new triggers after this long")
+	tasksTriggersCmd.Flags().Bool("add", false, "Add the new triggers instead of replacing the existing ones")
+	tasksTriggersCmd.Flags().Bool("clear", false, "Remove every trigger")
+
+	for _, cmd := range []*cobra.Command{tasksRunCmd, tasksStopCmd, tasksTriggersCmd} {
+		addDryRunFlag(cmd)
+	}
+}
+
+// resolveTask finds a scheduled task by ID, key or name
+func resolveTask(ctx context.Context, c client.Client, name string) (models.TaskInfo, error) {
+	tasks, err := c.ListScheduledTasks(ctx, client.TaskQuery{})
+	if err != nil {
+		return models.TaskInfo{}, fmt.Errorf("failed to list scheduled tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		if strings.EqualFold(task.ID, name) || strings.EqualFold(task.Key, name) || strings.EqualFold(task.Name, name) {
+			return task, nil
+		}
+	}
+
+	return models.TaskInfo{}, fmt.Errorf("task %q not found, see 'jellyfin-cli tasks list --hidden'", name)
+}
+
+// withWaitTimeout bounds waiting for a task, where a zero timeout means waiting as long as it takes
+func withWaitTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// waitForTaskResult waits for a task run ending after since, showing a progress bar
+// on a terminal, then outputs the result and fails unless the run completed
+func waitForTaskResult(ctx context.Context, c client.Client, task models.TaskInfo, since time.Time, interval time.Duration) error {
+	result, err := waitForTask(ctx, c, task, since, interval, newTaskProgress(os.Stderr))
+	if err != nil {
+		return err
+	}
+
+	// Output
+	if err := printResult(output.Result{
+		Data: result,
+		Text: func(w io.Writer) {
+			outputTaskResultText(w, result)
+		},
+	}); err != nil {
+		return err
+	}
+
+	return taskResultError(result)
+}
+
+// waitForTask polls a task until a run ending after since has finished, reporting progress along the way
+func waitForTask(ctx context.Context, c client.Client, task models.TaskInfo, since time.Time, interval time.Duration, progress func(models.TaskInfo)) (*models.TaskResult, error) {
+	if interval <= 0 {
+		interval = defaultTaskPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			progress(models.TaskInfo{})
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("timed out waiting for %s, which is still running", task.Name)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := c.GetScheduledTask(ctx, task.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			progress(models.TaskInfo{})
+			return nil, fmt.Errorf("failed to check on %s: %w", task.Name, err)
+		}
+
+		result := current.LastExecutionResult
+		if current.State == models.TaskStateIdle && result != nil && result.EndTimeUTC.After(since) {
+			progress(models.TaskInfo{})
+			return result, nil
+		}
+
+		progress(*current)
+	}
+}
+
+// newTaskProgress returns a function drawing a task's progress bar on a terminal.
+// Calling it with an empty task clears the bar.
+func newTaskProgress(w io.Writer) func(models.TaskInfo) {
+	if !output.IsTerminal(w) {
+		return func(models.TaskInfo) {}
+	}
+
+	start := time.Now()
+	return func(task models.TaskInfo) {
+		if task.Name == "" {
+			fmt.Fprint(w, "\r\x1b[K")
+			return
+		}
+
+		elapsed := formatDuration(time.Since(start))
+		if task.State != models.TaskStateRunning || task.CurrentProgressPercentage == nil {
+			status := strings.ToLower(task.State)
+			if task.State == models.TaskStateIdle {
+				status = "waiting to start"
+			}
+			fmt.Fprintf(w, "\r\x1b[K%s: %s %s", task.Name, status, elapsed)
+			return
+		}
+
+		percent := min(max(*task.CurrentProgressPercentage, 0), 100)
+		filled := int(percent / 100 * progressBarWidth)
+		bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+		fmt.Fprintf(w, "\r\x1b[K%s [%s] %5.1f%% %s", task.Name, bar, percent, elapsed)
+	}
+}
+
+// taskResultError returns an error when a task run didn't complete successfully
+func taskResultError(result *models.TaskResult) error {
+	if result.Status == models.TaskStatusCompleted {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %s ended with status %s", errTaskFailed, result.Name, result.Status)
+	if result.ErrorMessage != "" {
+		err = fmt.Errorf("%w: %s", err, result.ErrorMessage)
+	}
+
+	return err
+}
+
+// parseTriggers builds task triggers from the triggers command's flags
+func parseTriggers(daily, weekly []string, intervals []time.Duration, startup bool, maxRuntime time.Duration) ([]models.TaskTrigger, error) {
+	var triggers []models.TaskTrigger
+	maxRuntimeTicks := models.DurationToTicks(maxRuntime)
+
+	for _, value := range daily {
+		timeOfDay, err := parseTimeOfDay(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --daily %q: %w", value, err)
+		}
+		triggers = append(triggers, models.TaskTrigger{Type: models.TriggerDaily, TimeOfDayTicks: timeOfDay, MaxRuntimeTicks: maxRuntimeTicks})
+	}
+
+	for _, value := range weekly {
+		dayName, clock, ok := strings.Cut(value, "@")
+		if !ok {
+			return nil, fmt.Errorf("invalid --weekly %q: use day@HH:MM, e.g. sunday@03:00", value)
+		}
+		day, err := parseWeekday(dayName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --weekly %q: %w", value, err)
+		}
+		timeOfDay, err := parseTimeOfDay(clock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --weekly %q: %w", value, err)
+		}
+		triggers = append(triggers, models.TaskTrigger{Type: models.TriggerWeekly, DayOfWeek: day.String(), TimeOfDayTicks: timeOfDay, MaxRuntimeTicks: maxRuntimeTicks})
+	}
+
+	for _, interval := range intervals {
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid --interval %s: must be positive", interval)
+		}
+		triggers = append(triggers, models.TaskTrigger{Type: models.TriggerInterval, IntervalTicks: models.DurationToTicks(interval), MaxRuntimeTicks: maxRuntimeTicks})
+	}
+
+	if startup {
+		triggers = append(triggers, models.TaskTrigger{Type: models.TriggerStartup, MaxRuntimeTicks: maxRuntimeTicks})
+	}
+
+	return triggers, nil
+}
+
+// parseTimeOfDay parses a 24-hour HH:MM time into ticks since midnight
+func parseTimeOfDay(value string) (int64, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errors.New("use a 24-hour time such as 03:00")
+	}
+
+	return models.DurationToTicks(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), nil
+}
+
+// parseWeekday parses a day of the week, which may be abbreviated to three letters
+func parseWeekday(value string) (time.Weekday, error) {
+	value = strings.ToLower(value)
+	if len(value) >= 3 {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.HasPrefix(strings.ToLower(day.String()), value) {
+				return day, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unknown day %q", value)
+}
+
+// taskStatusStyle colors the results of task runs
+func taskStatusStyle(value string) output.Color {
+	switch value {
+	case models.TaskStatusCompleted:
+		return output.ColorGreen
+	case models.TaskStatusFailed, models.TaskStatusAborted:
+		return output.ColorRed
+	case models.TaskStatusCancelled:
+		return output.ColorYellow
+	default:
+		return output.ColorNone
+	}
+}
+
+// outputTasksText outputs scheduled tasks in human-readable format
+func outputTasksText(w io.Writer, tasks []serverTask) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, "No scheduled tasks found")
+		return
+	}
+
+	category := ""
+	for _, task := range tasks {
+		if task.Category != category {
+			category = task.Category
+			fmt.Fprintf(w, "%s:\n", category)
+		}
+
+		state := task.State
+		if progress := task.Progress(); progress != "" {
+			state += " " + progress
+		}
+
+		last := "never run"
+		if task.LastExecutionResult != nil {
+			last = fmt.Sprintf("%s %s, took %s", task.LastResult(), task.LastRun(), task.LastDuration())
+		}
+
+		fmt.Fprintf(w, " - %s%s [%s] (%s) key: %s\n", serverTag(task.Server), task.Name, state, last, task.Key)
+	}
+}
+
+// outputTaskDetailText outputs a scheduled task in human-readable format
+func outputTaskDetailText(w io.Writer, task models.TaskInfo) {
+	field := func(label string, value any) {
+		fmt.Fprintf(w, "  %-18s %v\n", label+":", value)
+	}
+
+	fmt.Fprintf(w, "Task %s\n", task.Name)
+	field("Key", task.Key)
+	field("ID", task.ID)
+	field("Category", task.Category)
+	if task.Description != "" {
+		field("Description", task.Description)
+	}
+	field("State", task.State)
+	if progress := task.Progress(); progress != "" {
+		field("Progress", progress)
+	}
+
+	fmt.Fprintln(w, "Triggers:")
+	if len(task.Triggers) == 0 {
+		fmt.Fprintln(w, "  none, only runs when started manually")
+	}
+	for _, trigger := range task.Triggers {
+		fmt.Fprintf(w, "  - %s\n", trigger)
+	}
+
+	if result := task.LastExecutionResult; result != nil {
+		fmt.Fprintln(w, "Last Run:")
+		field("Status", result.Status)
+		field("Started", result.StartTimeUTC.Local().Format(time.DateTime))
+		field("Duration", formatDuration(result.Duration()))
+		if result.ErrorMessage != "" {
+			field("Error", result.ErrorMessage)
+		}
+	}
+}
+
+// outputTaskResultText outputs the result of a task run in human-readable format.
+// Errors are left to taskResultError, which reports them on stderr.
+func outputTaskResultText(w io.Writer, result *models.TaskResult) {
+	fmt.Fprintf(w, "%s %s in %s\n", result.Name, strings.ToLower(result.Status), formatDuration(result.Duration()))
+}
+
+// outputTriggersText outputs a task's triggers in human-readable format
+func outputTriggersText(w io.Writer, task models.TaskInfo) {
+	if len(task.Triggers) == 0 {
+		fmt.Fprintf(w, "%s has no triggers and only runs when started manually\n", task.Name)
+		return
+	}
+
+	fmt.Fprintf(w, "%s runs:\n", task.Name)
+	for _, trigger := range task.Triggers {
+		fmt.Fprintf(w, " - %s\n", trigger)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/client"
+	"github.com/jfenske89/jellyfin-cli/pkg/models"
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// serverUser is a user tagged with the server it was found on
+type serverUser struct {
+	Server string `json:"Server,omitempty"`
+	models.User
+}
+
+// usersCmd represents the users command
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "List users on the Jellyfin server",
+	Long: `List the users on the Jellyfin server and when they were last active.
+
+Use --all to list every user instead of stopping at --limit.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get command flags
+		opts, err := pageOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Get users
+		results, err := fanOut(cmd.Context(), func(ctx context.Context, c client.Client) ([]models.User, error) {
+			users, _, err := collect(ctx, c.PageUsers(client.UserQuery{}, opts))
+			return users, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+
+		users := make([]serverUser, 0)
+		for _, result := range results {
+			for _, user := range result.Value {
+				users = append(users, serverUser{Server: result.Server, User: user})
+			}
+		}
+
+		// Output
+		return printResult(output.Result{
+			Data:    users,
+			Columns: withServerColumn("Name", "Id", "LastLoginDate", "LastActivityDate"),
+			Text: func(w io.Writer) {
+				outputUsersText(w, users)
+			},
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(usersCmd)
+
+	// Add local flags
+	addPagingFlags(usersCmd, 50)
+}
+
+// outputUsersText outputs users in human-readable format
+func outputUsersText(w io.Writer, users []serverUser) {
+	if len(users) == 0 {
+		fmt.Fprintln(w, "No users found")
+		return
+	}
+
+	fmt.Fprintln(w, "Users:")
+	for _, user := range users {
+		lastActive := "never"
+		if !user.LastActivityUTC.IsZero() {
+			lastActive = humanize.RelTime(time.Now(), user.LastActivityUTC, "", "ago")
+		}
+
+		fmt.Fprintf(w, " - %s%s (last active: %s)\n", serverTag(user.Server), user.Name, lastActive)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jfenske89/jellyfin-cli/pkg/output"
+)
+
+// defaultWatchInterval is used when --watch is given without an interval
+const defaultWatchInterval = 5 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\x1b[H\x1b[2J"
+
+// addWatchFlag adds the --watch flag, which takes an optional interval
+func addWatchFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration("watch", 0, fmt.Sprintf("Refresh the output until interrupted, optionally every --watch=<interval> (default %s)", defaultWatchInterval))
+	cmd.Flags().Lookup("watch").NoOptDefVal = defaultWatchInterval.String()
+}
+
+// watch refreshes a result every interval until the context is cancelled.
+// On a terminal the output is redrawn in place; otherwise each refresh is appended.
+// Failed refreshes are reported and retried on the next tick.
+func watch(ctx context.Context, title string, interval time.Duration, refresh func(ctx context.Context) (output.Result, error)) error {
+	if interval <= 0 {
+		return errors.New("watch interval must be positive")
+	}
+
+	redraw := output.IsTerminal(os.Stdout)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Render into a buffer first so the screen is only cleared once there is something to show
+		var buf bytes.Buffer
+		result, err := refresh(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			err = printResultTo(&buf, result)
+		}
+
+		if redraw {
+			fmt.Print(clearScreen)
+			fmt.Printf("Every %s: %s    %s\n\n", interval, title, time.Now().Format(time.RFC1123))
+		}
+		if err != nil {
+			logger.Errorw("Refresh failed", "error", err)
+		}
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
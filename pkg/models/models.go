@@ -3,6 +3,8 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -10,7 +12,34 @@ import (
 type JellyfinConfig struct {
 	BaseURL       string `json:"base_url" yaml:"base_url"`
 	Token         string `json:"token" yaml:"token"`
+	UserID        string `json:"user_id" yaml:"user_id"`
+	DeviceID      string `json:"device_id" yaml:"device_id"`
 	SkipSSLVerify bool   `json:"insecure" yaml:"insecure"`
+
+	// Retries is how many times idempotent requests are retried after a transient failure
+	Retries int `json:"retries" yaml:"retries"`
+
+	// Timeout bounds each request, 30 seconds when zero
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+
+	// RateLimit caps the number of requests per second, unlimited when zero
+	RateLimit float64 `json:"rate_limit" yaml:"rate_limit"`
+
+	// CACert is a PEM bundle of certificate authorities to trust besides the system ones
+	CACert string `json:"ca_cert" yaml:"ca_cert"`
+
+	// ClientCert and ClientKey are PEM files of a certificate presented to servers requiring mutual TLS
+	ClientCert string `json:"client_cert" yaml:"client_cert"`
+	ClientKey  string `json:"client_key" yaml:"client_key"`
+
+	// Proxy is the URL of an http, https or socks5 proxy to connect through
+	Proxy string `json:"proxy" yaml:"proxy"`
+
+	// Headers are extra headers sent with every request
+	Headers map[string]string `json:"headers" yaml:"headers"`
+
+	// UnixSocket is the path of a Unix domain socket to connect to instead of the base URL's host
+	UnixSocket string `json:"unix_socket" yaml:"unix_socket"`
 }
 
 // LogConfig holds the logging configuration
@@ -20,29 +49,399 @@ type LogConfig struct {
 
 // Config represents the application configuration
 type Config struct {
-	Jellyfin JellyfinConfig `json:"api" yaml:"api"`
-	Logging  LogConfig      `json:"logging" yaml:"logging"`
+	Jellyfin      JellyfinConfig            `json:"api" yaml:"api"`
+	Servers       map[string]JellyfinConfig `json:"servers" yaml:"servers"`
+	DefaultServer string                    `json:"default_server" yaml:"default_server"`
+	Logging       LogConfig                 `json:"logging" yaml:"logging"`
+}
+
+// User represents a Jellyfin user
+type User struct {
+	Name            string    `json:"Name"`
+	ID              string    `json:"Id"`
+	ServerID        string    `json:"ServerId"`
+	HasPassword     bool      `json:"HasPassword"`
+	LastLoginUTC    time.Time `json:"LastLoginDate"`
+	LastActivityUTC time.Time `json:"LastActivityDate"`
+}
+
+// AuthenticationResult represents the response to a successful login
+type AuthenticationResult struct {
+	User        User   `json:"User"`
+	AccessToken string `json:"AccessToken"`
+	ServerID    string `json:"ServerId"`
 }
 
 // Session represents a Jellyfin user session
 type Session struct {
-	DeviceName      string    `json:"DeviceName"`
-	UserName        string    `json:"UserName"`
-	LastActivityUTC time.Time `json:"LastActivityDate"`
-	ClientName      string    `json:"Client"`
-	ID              string    `json:"Id"`
+	DeviceName            string           `json:"DeviceName"`
+	UserName              string           `json:"UserName"`
+	LastActivityUTC       time.Time        `json:"LastActivityDate"`
+	ClientName            string           `json:"Client"`
+	ID                    string           `json:"Id"`
+	UserID                string           `json:"UserId"`
+	DeviceID              string           `json:"DeviceId"`
+	ApplicationVersion    string           `json:"ApplicationVersion"`
+	RemoteEndPoint        string           `json:"RemoteEndPoint"`
+	SupportsRemoteControl bool             `json:"SupportsRemoteControl"`
+	NowPlayingItem        *NowPlayingItem  `json:"NowPlayingItem,omitempty"`
+	PlayState             *PlayState       `json:"PlayState,omitempty"`
+	TranscodingInfo       *TranscodingInfo `json:"TranscodingInfo,omitempty"`
+}
+
+// ActiveSessionWindow is how recently a session must have been used to count as active
+const ActiveSessionWindow = 10 * time.Minute
+
+// State summarizes the session as Playing, Paused, Active or Idle
+func (s Session) State() string {
+	if s.NowPlayingItem != nil {
+		if s.PlayState != nil && s.PlayState.IsPaused {
+			return "Paused"
+		}
+		return "Playing"
+	}
+
+	if time.Since(s.LastActivityUTC) <= ActiveSessionWindow {
+		return "Active"
+	}
+
+	return "Idle"
+}
+
+// TicksPerSecond is the number of Jellyfin ticks (100ns units) in a second
+const TicksPerSecond = 10_000_000
+
+// TicksToDuration converts Jellyfin ticks to a duration
+func TicksToDuration(ticks int64) time.Duration {
+	return time.Duration(ticks) * 100 * time.Nanosecond
+}
+
+// DurationToTicks converts a duration to Jellyfin ticks
+func DurationToTicks(d time.Duration) int64 {
+	return int64(d / (100 * time.Nanosecond))
+}
+
+// NowPlayingItem represents the item being played in a session
+type NowPlayingItem struct {
+	Name           string `json:"Name"`
+	ID             string `json:"Id"`
+	Type           string `json:"Type"`
+	MediaType      string `json:"MediaType"`
+	SeriesName     string `json:"SeriesName,omitempty"`
+	SeasonName     string `json:"SeasonName,omitempty"`
+	EpisodeNum     int    `json:"IndexNumber,omitempty"`
+	SeasonNum      int    `json:"ParentIndexNumber,omitempty"`
+	ProductionYear int    `json:"ProductionYear,omitempty"`
+	RunTimeTicks   int64  `json:"RunTimeTicks"`
+}
+
+// Runtime returns the length of the item
+func (i NowPlayingItem) Runtime() time.Duration {
+	return TicksToDuration(i.RunTimeTicks)
+}
+
+// PlayState represents the playback state of a session
+type PlayState struct {
+	PositionTicks int64  `json:"PositionTicks"`
+	CanSeek       bool   `json:"CanSeek"`
+	IsPaused      bool   `json:"IsPaused"`
+	IsMuted       bool   `json:"IsMuted"`
+	VolumeLevel   int    `json:"VolumeLevel"`
+	PlayMethod    string `json:"PlayMethod"`
+	RepeatMode    string `json:"RepeatMode"`
+}
+
+// Position returns the current playback position
+func (p PlayState) Position() time.Duration {
+	return TicksToDuration(p.PositionTicks)
+}
+
+// TranscodingInfo represents how a session's stream is being transcoded
+type TranscodingInfo struct {
+	AudioCodec               string   `json:"AudioCodec"`
+	VideoCodec               string   `json:"VideoCodec"`
+	Container                string   `json:"Container"`
+	IsVideoDirect            bool     `json:"IsVideoDirect"`
+	IsAudioDirect            bool     `json:"IsAudioDirect"`
+	Bitrate                  int64    `json:"Bitrate"`
+	Framerate                float64  `json:"Framerate"`
+	CompletionPercentage     float64  `json:"CompletionPercentage"`
+	Width                    int      `json:"Width"`
+	Height                   int      `json:"Height"`
+	AudioChannels            int      `json:"AudioChannels"`
+	HardwareAccelerationType string   `json:"HardwareAccelerationType"`
+	TranscodeReasons         []string `json:"TranscodeReasons"`
+}
+
+// PlaystateCommand is a playback command sent to a session
+type PlaystateCommand string
+
+const (
+	PlaystateStop          PlaystateCommand = "Stop"
+	PlaystatePause         PlaystateCommand = "Pause"
+	PlaystateUnpause       PlaystateCommand = "Unpause"
+	PlaystatePlayPause     PlaystateCommand = "PlayPause"
+	PlaystateNextTrack     PlaystateCommand = "NextTrack"
+	PlaystatePreviousTrack PlaystateCommand = "PreviousTrack"
+	PlaystateSeek          PlaystateCommand = "Seek"
+	PlaystateRewind        PlaystateCommand = "Rewind"
+	PlaystateFastForward   PlaystateCommand = "FastForward"
+)
+
+// PlayCommand controls where items sent to a session are queued
+type PlayCommand string
+
+const (
+	PlayNow  PlayCommand = "PlayNow"
+	PlayNext PlayCommand = "PlayNext"
+	PlayLast PlayCommand = "PlayLast"
+)
+
+// GeneralCommand represents a remote control command sent to a session
+type GeneralCommand struct {
+	Name      string            `json:"Name"`
+	Arguments map[string]string `json:"Arguments,omitempty"`
+}
+
+// MessageCommand represents a message displayed on a session's client
+type MessageCommand struct {
+	Header    string `json:"Header,omitempty"`
+	Text      string `json:"Text"`
+	TimeoutMs int64  `json:"TimeoutMs,omitempty"`
+}
+
+// LibraryCollectionTypes are the kinds of content a library folder can hold
+var LibraryCollectionTypes = []string{"movies", "tvshows", "music", "musicvideos", "homevideos", "boxsets", "books", "mixed"}
+
+// MediaPathInfo is a folder on the server that a library reads media from
+type MediaPathInfo struct {
+	Path string `json:"Path"`
 }
 
 // LibraryFolder represents a Jellyfin library folder
 type LibraryFolder struct {
 	Name               string                 `json:"Name"`
 	CollectionType     string                 `json:"CollectionType"`
+	Locations          []string               `json:"Locations"`
+	LibraryOptions     LibraryOptions         `json:"LibraryOptions"`
 	RefreshStatus      string                 `json:"RefreshStatus"`
+	RefreshProgress    *float64               `json:"RefreshProgress,omitempty"`
 	ItemID             string                 `json:"ItemId"`
 	PrimaryImageTag    string                 `json:"PrimaryImageTag"`
 	AdditionalMetadata map[string]interface{} `json:"-"`
 }
 
+// LibraryOptions are the settings of a library folder.
+// The server replaces all of them on every update, so settings this type doesn't
+// know about are kept in Extra and sent back unchanged, while settings it knows
+// about but the server didn't send are left out, unless marked with MarkChanged.
+type LibraryOptions struct {
+	Enabled                                 bool                 `json:"Enabled"`
+	EnablePhotos                            bool                 `json:"EnablePhotos"`
+	EnableRealtimeMonitor                   bool                 `json:"EnableRealtimeMonitor"`
+	EnableLUFSScan                          bool                 `json:"EnableLUFSScan"`
+	EnableChapterImageExtraction            bool                 `json:"EnableChapterImageExtraction"`
+	ExtractChapterImagesDuringLibraryScan   bool                 `json:"ExtractChapterImagesDuringLibraryScan"`
+	EnableTrickplayImageExtraction          bool                 `json:"EnableTrickplayImageExtraction"`
+	ExtractTrickplayImagesDuringLibraryScan bool                 `json:"ExtractTrickplayImagesDuringLibraryScan"`
+	PathInfos                               []MediaPathInfo      `json:"PathInfos"`
+	SaveLocalMetadata                       bool                 `json:"SaveLocalMetadata"`
+	EnableAutomaticSeriesGrouping           bool                 `json:"EnableAutomaticSeriesGrouping"`
+	EnableEmbeddedTitles                    bool                 `json:"EnableEmbeddedTitles"`
+	EnableEmbeddedExtrasTitles              bool                 `json:"EnableEmbeddedExtrasTitles"`
+	EnableEmbeddedEpisodeInfos              bool                 `json:"EnableEmbeddedEpisodeInfos"`
+	AutomaticRefreshIntervalDays            int                  `json:"AutomaticRefreshIntervalDays"`
+	PreferredMetadataLanguage               string               `json:"PreferredMetadataLanguage"`
+	MetadataCountryCode                     string               `json:"MetadataCountryCode"`
+	SeasonZeroDisplayName                   string               `json:"SeasonZeroDisplayName"`
+	MetadataSavers                          []string             `json:"MetadataSavers"`
+	DisabledLocalMetadataReaders            []string             `json:"DisabledLocalMetadataReaders"`
+	LocalMetadataReaderOrder                []string             `json:"LocalMetadataReaderOrder"`
+	DisabledSubtitleFetchers                []string             `json:"DisabledSubtitleFetchers"`
+	SubtitleFetcherOrder                    []string             `json:"SubtitleFetcherOrder"`
+	DisabledLyricFetchers                   []string             `json:"DisabledLyricFetchers"`
+	LyricFetcherOrder                       []string             `json:"LyricFetcherOrder"`
+	SkipSubtitlesIfEmbeddedSubtitlesPresent bool                 `json:"SkipSubtitlesIfEmbeddedSubtitlesPresent"`
+	SkipSubtitlesIfAudioTrackMatches        bool                 `json:"SkipSubtitlesIfAudioTrackMatches"`
+	SubtitleDownloadLanguages               []string             `json:"SubtitleDownloadLanguages"`
+	RequirePerfectSubtitleMatch             bool                 `json:"RequirePerfectSubtitleMatch"`
+	SaveSubtitlesWithMedia                  bool                 `json:"SaveSubtitlesWithMedia"`
+	SaveLyricsWithMedia                     bool                 `json:"SaveLyricsWithMedia"`
+	SaveTrickplayWithMedia                  bool                 `json:"SaveTrickplayWithMedia"`
+	TypeOptions                             []LibraryTypeOptions `json:"TypeOptions"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// received holds the names of the settings the server sent or that were marked
+	// as changed, nil when not decoded from JSON
+	received map[string]bool
+}
+
+// MarkChanged records that the setting with the given JSON name was changed,
+// so it is sent even when the server didn't send it and its value is zero
+func (o *LibraryOptions) MarkChanged(name string) {
+	if o.received == nil {
+		return
+	}
+
+	o.received[name] = true
+}
+
+// libraryOptions has the fields of LibraryOptions without its JSON methods
+type libraryOptions LibraryOptions
+
+// UnmarshalJSON decodes library options, keeping unknown settings in Extra
+func (o *LibraryOptions) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*libraryOptions)(o)); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	o.received = make(map[string]bool, len(all))
+	for name := range all {
+		o.received[name] = true
+	}
+	for _, name := range jsonFieldNames(reflect.TypeFor[libraryOptions]()) {
+		delete(all, name)
+	}
+
+	o.Extra = all
+	return nil
+}
+
+// MarshalJSON encodes library options along with the unknown settings in Extra
+func (o LibraryOptions) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(libraryOptions(o))
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	// Leave out what the server didn't send and wasn't changed, so its defaults aren't
+	// overwritten with zero values
+	for name, value := range all {
+		if o.received != nil && !o.received[name] && isZeroJSON(value) {
+			delete(all, name)
+		}
+	}
+	for name, value := range o.Extra {
+		if _, known := all[name]; !known {
+			all[name] = value
+		}
+	}
+
+	return json.Marshal(all)
+}
+
+// TypeOptionsFor returns the options for one type of item, such as Movie or Series, or nil if there are none
+func (o *LibraryOptions) TypeOptionsFor(itemType string) *LibraryTypeOptions {
+	for i := range o.TypeOptions {
+		if strings.EqualFold(o.TypeOptions[i].Type, itemType) {
+			return &o.TypeOptions[i]
+		}
+	}
+
+	return nil
+}
+
+// LibraryTypeOptions choose the metadata and image providers used for one type of item in a library
+type LibraryTypeOptions struct {
+	Type                 string   `json:"Type"`
+	MetadataFetchers     []string `json:"MetadataFetchers"`
+	MetadataFetcherOrder []string `json:"MetadataFetcherOrder"`
+	ImageFetchers        []string `json:"ImageFetchers"`
+	ImageFetcherOrder    []string `json:"ImageFetcherOrder"`
+
+	// ImageOptions are passed through unchanged
+	ImageOptions json.RawMessage `json:"ImageOptions,omitempty"`
+}
+
+// libraryTypeOptions has the fields of LibraryTypeOptions without its JSON methods
+type libraryTypeOptions LibraryTypeOptions
+
+// MarshalJSON encodes type options, sending provider lists that aren't set as empty
+// lists, so a cleared list clears the server's and no list is sent as null
+func (o LibraryTypeOptions) MarshalJSON() ([]byte, error) {
+	for _, list := range []*[]string{&o.MetadataFetchers, &o.MetadataFetcherOrder, &o.ImageFetchers, &o.ImageFetcherOrder} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+
+	return json.Marshal(libraryTypeOptions(o))
+}
+
+// AvailableLibraryOptions are the providers a library of some type can choose from
+type AvailableLibraryOptions struct {
+	MetadataSavers   []LibraryOptionInfo      `json:"MetadataSavers"`
+	MetadataReaders  []LibraryOptionInfo      `json:"MetadataReaders"`
+	SubtitleFetchers []LibraryOptionInfo      `json:"SubtitleFetchers"`
+	LyricFetchers    []LibraryOptionInfo      `json:"LyricFetchers"`
+	TypeOptions      []LibraryTypeOptionsInfo `json:"TypeOptions"`
+}
+
+// LibraryTypeOptionsInfo are the providers available for one type of item
+type LibraryTypeOptionsInfo struct {
+	Type                string              `json:"Type"`
+	MetadataFetchers    []LibraryOptionInfo `json:"MetadataFetchers"`
+	ImageFetchers       []LibraryOptionInfo `json:"ImageFetchers"`
+	SupportedImageTypes []string            `json:"SupportedImageTypes"`
+}
+
+// LibraryOptionInfo is a provider that a library can use
+type LibraryOptionInfo struct {
+	Name           string `json:"Name"`
+	DefaultEnabled bool   `json:"DefaultEnabled"`
+}
+
+// Culture is a language that metadata can be fetched in
+type Culture struct {
+	Name                        string   `json:"Name"`
+	DisplayName                 string   `json:"DisplayName"`
+	TwoLetterISOLanguageName    string   `json:"TwoLetterISOLanguageName"`
+	ThreeLetterISOLanguageName  string   `json:"ThreeLetterISOLanguageName"`
+	ThreeLetterISOLanguageNames []string `json:"ThreeLetterISOLanguageNames"`
+}
+
+// Country is a country that metadata such as ratings can be fetched for
+type Country struct {
+	Name                     string `json:"Name"`
+	DisplayName              string `json:"DisplayName"`
+	TwoLetterISORegionName   string `json:"TwoLetterISORegionName"`
+	ThreeLetterISORegionName string `json:"ThreeLetterISORegionName"`
+}
+
+// isZeroJSON reports whether an encoded value is null, false, zero or empty
+func isZeroJSON(value json.RawMessage) bool {
+	switch string(value) {
+	case "null", "false", "0", `""`, "[]", "{}":
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonFieldNames returns the JSON names of a struct type's fields
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
 // ActivityLogItem represents a single activity log entry
 type ActivityLogItem struct {
 	ID               int64     `json:"Id"`
@@ -64,6 +463,36 @@ type ActivityLog struct {
 	StartIndex int               `json:"StartIndex"`
 }
 
+// QueryResult is a page of results from a Jellyfin list endpoint
+type QueryResult[T any] struct {
+	Items            []T `json:"Items"`
+	TotalRecordCount int `json:"TotalRecordCount"`
+	StartIndex       int `json:"StartIndex"`
+}
+
+// Item represents a library item such as a movie, series, episode or folder
+type Item struct {
+	Name           string    `json:"Name"`
+	ID             string    `json:"Id"`
+	Type           string    `json:"Type"`
+	MediaType      string    `json:"MediaType,omitempty"`
+	CollectionType string    `json:"CollectionType,omitempty"`
+	SeriesName     string    `json:"SeriesName,omitempty"`
+	EpisodeNum     int       `json:"IndexNumber,omitempty"`
+	SeasonNum      int       `json:"ParentIndexNumber,omitempty"`
+	ProductionYear int       `json:"ProductionYear,omitempty"`
+	RunTimeTicks   int64     `json:"RunTimeTicks,omitempty"`
+	ParentID       string    `json:"ParentId,omitempty"`
+	Path           string    `json:"Path,omitempty"`
+	IsFolder       bool      `json:"IsFolder"`
+	DateCreated    time.Time `json:"DateCreated,omitzero"`
+}
+
+// Runtime returns the length of the item
+func (i Item) Runtime() time.Duration {
+	return TicksToDuration(i.RunTimeTicks)
+}
+
 // SearchHint represents a search hint returned from Jellyfin
 type SearchHint struct {
 	Name        string `json:"Name"`
@@ -83,6 +512,221 @@ type SearchResponse struct {
 	TotalHints  int          `json:"TotalRecordCount"`
 }
 
+// EventType identifies a message received over the WebSocket connection
+type EventType string
+
+const (
+	EventSessions           EventType = "Sessions"
+	EventLibraryChanged     EventType = "LibraryChanged"
+	EventUserDataChanged    EventType = "UserDataChanged"
+	EventScheduledTaskEnded EventType = "ScheduledTaskEnded"
+	EventActivityLogEntry   EventType = "ActivityLogEntry"
+	EventKeepAlive          EventType = "KeepAlive"
+	EventForceKeepAlive     EventType = "ForceKeepAlive"
+)
+
+// EventSubscriptions are the event types the server only sends after a "<type>Start" message
+var EventSubscriptions = []EventType{EventSessions, EventActivityLogEntry}
+
+// Event is a message received over the WebSocket connection
+type Event struct {
+	MessageType EventType       `json:"MessageType"`
+	MessageID   string          `json:"MessageId,omitempty"`
+	Data        json.RawMessage `json:"Data,omitempty"`
+}
+
+// Payload decodes the event data into the type matching its message type.
+// Sessions decode to []Session, LibraryChanged to *LibraryUpdateInfo,
+// UserDataChanged to *UserDataChangeInfo, ScheduledTaskEnded to *TaskResult
+// and ActivityLogEntry to []ActivityLogItem; other types decode generically.
+func (e Event) Payload() (any, error) {
+	var payload any
+	switch e.MessageType {
+	case EventSessions:
+		payload = &[]Session{}
+	case EventLibraryChanged:
+		payload = &LibraryUpdateInfo{}
+	case EventUserDataChanged:
+		payload = &UserDataChangeInfo{}
+	case EventScheduledTaskEnded:
+		payload = &TaskResult{}
+	case EventActivityLogEntry:
+		payload = &[]ActivityLogItem{}
+	default:
+		payload = new(any)
+	}
+
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, payload); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event: %w", e.MessageType, err)
+		}
+	}
+
+	// Return slices and generic values directly rather than pointers to them
+	switch p := payload.(type) {
+	case *[]Session:
+		return *p, nil
+	case *[]ActivityLogItem:
+		return *p, nil
+	case *any:
+		return *p, nil
+	default:
+		return payload, nil
+	}
+}
+
+// LibraryUpdateInfo describes items added, updated or removed in a library scan
+type LibraryUpdateInfo struct {
+	FoldersAddedTo     []string `json:"FoldersAddedTo"`
+	FoldersRemovedFrom []string `json:"FoldersRemovedFrom"`
+	ItemsAdded         []string `json:"ItemsAdded"`
+	ItemsRemoved       []string `json:"ItemsRemoved"`
+	ItemsUpdated       []string `json:"ItemsUpdated"`
+	CollectionFolders  []string `json:"CollectionFolders"`
+	IsEmpty            bool     `json:"IsEmpty"`
+}
+
+// UserDataChangeInfo describes changes to a user's played state, favorites and positions
+type UserDataChangeInfo struct {
+	UserID       string         `json:"UserId"`
+	UserDataList []UserItemData `json:"UserDataList"`
+}
+
+// UserItemData is a user's data for a single item
+type UserItemData struct {
+	ItemID                string     `json:"ItemId"`
+	Key                   string     `json:"Key"`
+	PlaybackPositionTicks int64      `json:"PlaybackPositionTicks"`
+	PlayCount             int        `json:"PlayCount"`
+	IsFavorite            bool       `json:"IsFavorite"`
+	Played                bool       `json:"Played"`
+	LastPlayedDate        *time.Time `json:"LastPlayedDate,omitempty"`
+}
+
+// TaskResult describes the outcome of a scheduled task run
+type TaskResult struct {
+	ID               string    `json:"Id"`
+	Key              string    `json:"Key"`
+	Name             string    `json:"Name"`
+	Status           string    `json:"Status"`
+	StartTimeUTC     time.Time `json:"StartTimeUtc"`
+	EndTimeUTC       time.Time `json:"EndTimeUtc"`
+	ErrorMessage     string    `json:"ErrorMessage,omitempty"`
+	LongErrorMessage string    `json:"LongErrorMessage,omitempty"`
+}
+
+// Duration returns how long the task ran
+func (r TaskResult) Duration() time.Duration {
+	if r.StartTimeUTC.IsZero() || r.EndTimeUTC.IsZero() {
+		return 0
+	}
+
+	return r.EndTimeUTC.Sub(r.StartTimeUTC)
+}
+
+// Scheduled task states
+const (
+	TaskStateIdle       = "Idle"
+	TaskStateRunning    = "Running"
+	TaskStateCancelling = "Cancelling"
+)
+
+// Scheduled task result statuses
+const (
+	TaskStatusCompleted = "Completed"
+	TaskStatusFailed    = "Failed"
+	TaskStatusCancelled = "Cancelled"
+	TaskStatusAborted   = "Aborted"
+)
+
+// Scheduled task trigger types
+const (
+	TriggerDaily    = "DailyTrigger"
+	TriggerWeekly   = "WeeklyTrigger"
+	TriggerInterval = "IntervalTrigger"
+	TriggerStartup  = "StartupTrigger"
+)
+
+// TaskInfo describes a scheduled task
+type TaskInfo struct {
+	ID          string `json:"Id"`
+	Key         string `json:"Key"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+	Category    string `json:"Category"`
+	State       string `json:"State"`
+	IsHidden    bool   `json:"IsHidden"`
+
+	// CurrentProgressPercentage is only set while the task is running
+	CurrentProgressPercentage *float64 `json:"CurrentProgressPercentage,omitempty"`
+
+	LastExecutionResult *TaskResult   `json:"LastExecutionResult,omitempty"`
+	Triggers            []TaskTrigger `json:"Triggers"`
+}
+
+// Progress returns the progress of a running task as a percentage, or an empty string
+func (t TaskInfo) Progress() string {
+	if t.State != TaskStateRunning || t.CurrentProgressPercentage == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%.1f%%", *t.CurrentProgressPercentage)
+}
+
+// TaskTrigger describes when a scheduled task runs
+type TaskTrigger struct {
+	Type string `json:"Type"`
+
+	// TimeOfDayTicks is the time of day daily and weekly triggers fire at
+	TimeOfDayTicks int64 `json:"TimeOfDayTicks,omitempty"`
+
+	// IntervalTicks is the time between runs of an interval trigger
+	IntervalTicks int64 `json:"IntervalTicks,omitempty"`
+
+	// DayOfWeek is the day weekly triggers fire on, e.g. Sunday
+	DayOfWeek string `json:"DayOfWeek,omitempty"`
+
+	// MaxRuntimeTicks stops the task when it runs longer than this
+	MaxRuntimeTicks int64 `json:"MaxRuntimeTicks,omitempty"`
+}
+
+// Schedule describes when the trigger fires, e.g. "daily at 03:00"
+func (t TaskTrigger) Schedule() string {
+	timeOfDay := TicksToDuration(t.TimeOfDayTicks)
+	clock := fmt.Sprintf("%02d:%02d", int(timeOfDay.Hours()), int(timeOfDay.Minutes())%60)
+
+	switch t.Type {
+	case TriggerDaily:
+		return "daily at " + clock
+	case TriggerWeekly:
+		return fmt.Sprintf("weekly on %s at %s", t.DayOfWeek, clock)
+	case TriggerInterval:
+		return "every " + TicksToDuration(t.IntervalTicks).String()
+	case TriggerStartup:
+		return "on startup"
+	default:
+		return t.Type
+	}
+}
+
+// MaxRuntime returns how long runs started by the trigger may take, or "" without a limit
+func (t TaskTrigger) MaxRuntime() string {
+	if t.MaxRuntimeTicks <= 0 {
+		return ""
+	}
+
+	return TicksToDuration(t.MaxRuntimeTicks).String()
+}
+
+// String describes the trigger, including its runtime limit
+func (t TaskTrigger) String() string {
+	if maxRuntime := t.MaxRuntime(); maxRuntime != "" {
+		return fmt.Sprintf("%s (max runtime %s)", t.Schedule(), maxRuntime)
+	}
+
+	return t.Schedule()
+}
+
 // UnmarshalJSON is a custom unmarshaler for time.Time fields in Jellyfin API responses
 func ParseJellyfinTime(data []byte) (time.Time, error) {
 	var timeStr string
@@ -97,3 +741,37 @@ func ParseJellyfinTime(data []byte) (time.Time, error) {
 
 	return t, nil
 }
+
+// PublicSystemInfo is the server information available without authentication
+type PublicSystemInfo struct {
+	LocalAddress           string `json:"LocalAddress"`
+	ServerName             string `json:"ServerName"`
+	Version                string `json:"Version"`
+	ProductName            string `json:"ProductName"`
+	OperatingSystem        string `json:"OperatingSystem"`
+	ID                     string `json:"Id"`
+	StartupWizardCompleted bool   `json:"StartupWizardCompleted"`
+}
+
+// SystemInfo is the full server information, available to administrators
+type SystemInfo struct {
+	PublicSystemInfo
+
+	OperatingSystemDisplayName string `json:"OperatingSystemDisplayName"`
+	SystemArchitecture         string `json:"SystemArchitecture"`
+	PackageName                string `json:"PackageName"`
+	HasPendingRestart          bool   `json:"HasPendingRestart"`
+	IsShuttingDown             bool   `json:"IsShuttingDown"`
+	HasUpdateAvailable         bool   `json:"HasUpdateAvailable"`
+	CanSelfRestart             bool   `json:"CanSelfRestart"`
+	SupportsLibraryMonitor     bool   `json:"SupportsLibraryMonitor"`
+	WebSocketPortNumber        int    `json:"WebSocketPortNumber"`
+
+	ProgramDataPath      string `json:"ProgramDataPath"`
+	WebPath              string `json:"WebPath"`
+	ItemsByNamePath      string `json:"ItemsByNamePath"`
+	CachePath            string `json:"CachePath"`
+	LogPath              string `json:"LogPath"`
+	InternalMetadataPath string `json:"InternalMetadataPath"`
+	TranscodingTempPath  string `json:"TranscodingTempPath"`
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// marshalFields encodes library options and decodes the result into its raw fields
+func marshalFields(t *testing.T, options LibraryOptions) map[string]json.RawMessage {
+	t.Helper()
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		t.Fatalf("failed to encode library options: %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("failed to decode encoded library options: %v", err)
+	}
+
+	return fields
+}
+
+func TestLibraryOptionsRoundTrip(t *testing.T) {
+	const received = `{
+		"Enabled": true,
+		"SaveLocalMetadata": false,
+		"PreferredMetadataLanguage": "",
+		"AutomaticRefreshIntervalDays": 30,
+		"MetadataSavers": ["Nfo"],
+		"TypeOptions": [{"Type": "Movie", "MetadataFetchers": ["TheMovieDb"], "ImageOptions": [{"Type": "Primary", "Limit": 1}]}],
+		"FutureSetting": {"Nested": [1, 2]},
+		"FutureFlag": false
+	}`
+
+	tests := []struct {
+		name    string
+		change  func(o *LibraryOptions)
+		want    map[string]string
+		missing []string
+	}{
+		{
+			name: "unchanged",
+			want: map[string]string{
+				"Enabled":                      `true`,
+				"SaveLocalMetadata":            `false`,
+				"PreferredMetadataLanguage":    `""`,
+				"AutomaticRefreshIntervalDays": `30`,
+				"MetadataSavers":               `["Nfo"]`,
+				"TypeOptions":                  `[{"Type":"Movie","MetadataFetchers":["TheMovieDb"],"MetadataFetcherOrder":[],"ImageFetchers":[],"ImageFetcherOrder":[],"ImageOptions":[{"Type":"Primary","Limit":1}]}]`,
+				"FutureSetting":                `{"Nested":[1,2]}`,
+				"FutureFlag":                   `false`,
+			},
+			missing: []string{"EnableLUFSScan", "SeasonZeroDisplayName", "DisabledSubtitleFetchers"},
+		},
+		{
+			name: "changed zero value",
+			change: func(o *LibraryOptions) {
+				o.EnableLUFSScan = false
+				o.MarkChanged("EnableLUFSScan")
+				o.DisabledSubtitleFetchers = []string{}
+				o.MarkChanged("DisabledSubtitleFetchers")
+			},
+			want:    map[string]string{"EnableLUFSScan": `false`, "DisabledSubtitleFetchers": `[]`},
+			missing: []string{"SeasonZeroDisplayName"},
+		},
+		{
+			name:   "changed value not received",
+			change: func(o *LibraryOptions) { o.SeasonZeroDisplayName = "Specials" },
+			want:   map[string]string{"SeasonZeroDisplayName": `"Specials"`},
+		},
+		{
+			name:    "received value cleared",
+			change:  func(o *LibraryOptions) { o.Enabled = false },
+			want:    map[string]string{"Enabled": `false`},
+			missing: []string{"EnableLUFSScan"},
+		},
+		{
+			name: "extra setting changed",
+			change: func(o *LibraryOptions) {
+				o.Extra["FutureFlag"] = json.RawMessage(`true`)
+				o.MarkChanged("FutureFlag")
+			},
+			want: map[string]string{"FutureFlag": `true`, "FutureSetting": `{"Nested":[1,2]}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options LibraryOptions
+			if err := json.Unmarshal([]byte(received), &options); err != nil {
+				t.Fatalf("failed to decode library options: %v", err)
+			}
+			if tt.change != nil {
+				tt.change(&options)
+			}
+
+			fields := marshalFields(t, options)
+			for name, want := range tt.want {
+				got, ok := fields[name]
+				if !ok {
+					t.Errorf("%s was left out, want %s", name, want)
+					continue
+				}
+				if compact := compactJSON(t, got); compact != want {
+					t.Errorf("%s = %s, want %s", name, compact, want)
+				}
+			}
+			for _, name := range tt.missing {
+				if value, ok := fields[name]; ok {
+					t.Errorf("%s = %s, want it left out", name, value)
+				}
+			}
+		})
+	}
+}
+
+func TestLibraryOptionsExtra(t *testing.T) {
+	var options LibraryOptions
+	if err := json.Unmarshal([]byte(`{"Enabled": true, "FutureSetting": 5}`), &options); err != nil {
+		t.Fatalf("failed to decode library options: %v", err)
+	}
+
+	if len(options.Extra) != 1 || string(options.Extra["FutureSetting"]) != "5" {
+		t.Errorf("Extra = %v, want only FutureSetting", options.Extra)
+	}
+	if _, ok := options.Extra["Enabled"]; ok {
+		t.Error("known setting Enabled was kept in Extra")
+	}
+}
+
+func TestLibraryOptionsNotDecoded(t *testing.T) {
+	// Options built from scratch send every setting
+	fields := marshalFields(t, LibraryOptions{})
+	for _, name := range []string{"Enabled", "EnableLUFSScan", "SeasonZeroDisplayName", "AutomaticRefreshIntervalDays"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("%s was left out", name)
+		}
+	}
+
+	// MarkChanged is harmless on them
+	options := LibraryOptions{}
+	options.MarkChanged("EnableLUFSScan")
+	if _, ok := marshalFields(t, options)["EnableLUFSScan"]; !ok {
+		t.Error("EnableLUFSScan was left out after MarkChanged")
+	}
+}
+
+// compactJSON removes the insignificant whitespace from a JSON value
+func compactJSON(t *testing.T, value json.RawMessage) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to compact %s: %v", value, err)
+	}
+
+	return string(data)
+}
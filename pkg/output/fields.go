@@ -0,0 +1,164 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Field looks up a value in a record by its JSON field name.
+// Nested fields are separated by dots, e.g. "NowPlayingItem.Name".
+func Field(record reflect.Value, path string) (reflect.Value, bool) {
+	if record.IsValid() && record.CanInterface() {
+		if row, ok := record.Interface().(projectedRow); ok {
+			return row.field(path)
+		}
+	}
+
+	current := record
+	for _, name := range strings.Split(path, ".") {
+		current = indirect(current)
+		if !current.IsValid() {
+			return reflect.Value{}, false
+		}
+
+		switch current.Kind() {
+		case reflect.Struct:
+			field, ok := structField(current, name)
+			if !ok {
+				if field, ok = methodValue(current, name); !ok {
+					return reflect.Value{}, false
+				}
+			}
+			current = field
+
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			current = current.MapIndex(reflect.ValueOf(name).Convert(current.Type().Key()))
+
+		default:
+			return reflect.Value{}, false
+		}
+	}
+
+	return indirect(current), true
+}
+
+// FieldString looks up a value by JSON field name and formats it for display
+func FieldString(record reflect.Value, path string) string {
+	value, ok := Field(record, path)
+	if !ok {
+		return ""
+	}
+
+	return formatValue(value)
+}
+
+// structField finds a struct field by its JSON name, searching embedded structs
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == "-" {
+			continue
+		}
+
+		// Embedded structs without a name are flattened, as in encoding/json
+		if field.Anonymous && tagName == "" {
+			embedded := indirect(v.Field(i))
+			if embedded.IsValid() && embedded.Kind() == reflect.Struct {
+				if found, ok := structField(embedded, name); ok {
+					return found, true
+				}
+			}
+			continue
+		}
+
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if strings.EqualFold(tagName, name) {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// methodValue calls an exported, argument-free method named like a column,
+// so records can expose computed columns such as a session's State
+func methodValue(v reflect.Value, name string) (reflect.Value, bool) {
+	candidates := []reflect.Value{v}
+	if v.CanAddr() {
+		candidates = append(candidates, v.Addr())
+	}
+
+	for _, candidate := range candidates {
+		t := candidate.Type()
+		for i := 0; i < t.NumMethod(); i++ {
+			method := t.Method(i)
+			if !strings.EqualFold(method.Name, name) || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 {
+				continue
+			}
+			return candidate.Method(i).Call(nil)[0], true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// indirect dereferences pointers and interfaces, returning an invalid value for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// formatValue renders a field value as a single string
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts[i] = formatValue(indirect(v.Index(i)))
+		}
+		return strings.Join(parts, ",")
+
+	case reflect.Map, reflect.Struct:
+		return fmt.Sprintf("%v", v.Interface())
+
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,502 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Format identifies how results are written
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatYAML     Format = "yaml"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatTemplate Format = "template"
+)
+
+// Formats lists every supported format, for help text and validation
+var Formats = []Format{
+	FormatText,
+	FormatTable,
+	FormatJSON,
+	FormatNDJSON,
+	FormatYAML,
+	FormatCSV,
+	FormatTSV,
+	FormatTemplate,
+}
+
+// Options configures a Printer
+type Options struct {
+	Format   Format
+	Template string
+
+	// Columns overrides the default columns and projects structured output
+	Columns []string
+
+	// SortBy sorts list results by a column, descending with a "-desc" suffix
+	SortBy string
+
+	// NoHeaders omits the header line of tabular formats
+	NoHeaders bool
+
+	// Query selects values from the JSON form of the result
+	Query string
+
+	// Color controls colorized table output
+	Color ColorMode
+
+	// Width limits table width; when zero it is detected from the writer
+	Width int
+}
+
+// ParseOptions parses an output flag value such as "json" or "template={{.Name}}"
+func ParseOptions(value string) (Options, error) {
+	name, tmpl, hasTemplate := strings.Cut(value, "=")
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+
+	if format == "" {
+		format = FormatText
+	}
+
+	if format == FormatTemplate {
+		if !hasTemplate || tmpl == "" {
+			return Options{}, fmt.Errorf("output format %q requires a template, e.g. template={{.Name}}", format)
+		}
+		return Options{Format: format, Template: tmpl}, nil
+	}
+
+	if hasTemplate {
+		return Options{}, fmt.Errorf("output format %q does not take a value", format)
+	}
+
+	for _, known := range Formats {
+		if format == known {
+			return Options{Format: format}, nil
+		}
+	}
+
+	return Options{}, fmt.Errorf("unknown output format %q", value)
+}
+
+// Result describes the output of a command
+type Result struct {
+	// Data is the complete result, used by the json and yaml formats
+	Data any
+
+	// Rows is the slice of records used by row-based formats.
+	// When nil, Data is used if it is a slice.
+	Rows any
+
+	// Columns are the fields shown by tabular formats, named as in JSON
+	Columns []string
+
+	// Styles color table cells, keyed by column
+	Styles map[string]StyleFunc
+
+	// Text renders the human-readable text format.
+	// When nil, the text format falls back to a table.
+	Text func(w io.Writer)
+}
+
+// rows returns the records of a result as a reflected slice, if it has any
+func (r Result) rows() (reflect.Value, bool) {
+	rows := r.Rows
+	if rows == nil {
+		rows = r.Data
+	}
+
+	v := reflect.ValueOf(rows)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}, false
+	}
+
+	return v, true
+}
+
+// Printer writes results in a configured format
+type Printer struct {
+	w     io.Writer
+	opts  Options
+	tmpl  *template.Template
+	query *Query
+	color bool
+	width int
+}
+
+// NewPrinter creates a printer writing to w
+func NewPrinter(w io.Writer, opts Options) (*Printer, error) {
+	p := &Printer{
+		w:     w,
+		opts:  opts,
+		color: ColorEnabled(opts.Color, w),
+		width: opts.Width,
+	}
+	if p.width == 0 {
+		p.width = TerminalWidth(w)
+	}
+
+	if opts.Query != "" {
+		query, err := ParseQuery(opts.Query)
+		if err != nil {
+			return nil, err
+		}
+		p.query = query
+	}
+
+	if opts.Format == FormatTemplate {
+		tmpl, err := template.New("output").Parse(opts.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output template: %w", err)
+		}
+		p.tmpl = tmpl
+	}
+
+	return p, nil
+}
+
+// Format returns the format the printer writes
+func (p *Printer) Format() Format {
+	return p.opts.Format
+}
+
+// Color reports whether the printer colorizes its output
+func (p *Printer) Color() bool {
+	return p.color
+}
+
+// Print writes a result in the configured format
+func (p *Printer) Print(r Result) error {
+	rows, hasRows := r.rows()
+
+	if hasRows && p.opts.SortBy != "" {
+		if err := sortRows(rows, p.opts.SortBy); err != nil {
+			return err
+		}
+	}
+
+	if hasRows && len(p.opts.Columns) > 0 {
+		if err := checkColumns(rows.Type().Elem(), p.opts.Columns); err != nil {
+			return err
+		}
+
+		// Selected columns replace the defaults and narrow structured output to those fields
+		r.Columns = p.opts.Columns
+		r.Data = projectRows(rows, r.Columns)
+		r.Rows = r.Data
+		r.Text = nil
+	}
+
+	if p.query != nil {
+		return p.printQuery(r.Data)
+	}
+
+	switch p.opts.Format {
+	case FormatText, "":
+		if r.Text != nil {
+			r.Text(p.w)
+			return nil
+		}
+		return p.printTable(r)
+
+	case FormatTable:
+		if _, ok := r.rows(); !ok && r.Text != nil {
+			r.Text(p.w)
+			return nil
+		}
+		return p.printTable(r)
+
+	case FormatJSON:
+		return p.printJSON(r.Data)
+
+	case FormatNDJSON:
+		return p.printNDJSON(r)
+
+	case FormatYAML:
+		return p.printYAML(r.Data)
+
+	case FormatCSV:
+		return p.printDelimited(r, ',')
+
+	case FormatTSV:
+		return p.printDelimited(r, '\t')
+
+	case FormatTemplate:
+		return p.printTemplate(r)
+
+	default:
+		return fmt.Errorf("unknown output format %q", p.opts.Format)
+	}
+}
+
+// printJSON writes data as indented JSON
+func (p *Printer) printJSON(data any) error {
+	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	_, err = fmt.Fprintln(p.w, string(jsonBytes))
+	return err
+}
+
+// printNDJSON writes one compact JSON document per row
+func (p *Printer) printNDJSON(r Result) error {
+	encoder := json.NewEncoder(p.w)
+
+	rows, ok := r.rows()
+	if !ok {
+		return encoder.Encode(r.Data)
+	}
+
+	for i := 0; i < rows.Len(); i++ {
+		if err := encoder.Encode(rows.Index(i).Interface()); err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// printYAML writes data as YAML, keeping the JSON field names and order
+func (p *Printer) printYAML(data any) error {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	// JSON is valid YAML, so decode it into a node tree to keep the field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return fmt.Errorf("failed to convert JSON to YAML: %w", err)
+	}
+	resetYAMLStyle(&node)
+
+	encoder := yaml.NewEncoder(p.w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+// resetYAMLStyle switches a node tree decoded from JSON to block style
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// printTable writes rows as aligned columns fitted to the terminal width
+func (p *Printer) printTable(r Result) error {
+	rows, ok := r.rows()
+	if !ok {
+		return p.printJSON(r.Data)
+	}
+
+	t := &table{
+		headers: r.Columns,
+		rows:    make([][]string, rows.Len()),
+		styles:  make([]StyleFunc, len(r.Columns)),
+	}
+
+	for i, column := range r.Columns {
+		for name, style := range r.Styles {
+			if strings.EqualFold(name, column) {
+				t.styles[i] = style
+			}
+		}
+	}
+
+	for i := range t.rows {
+		values := make([]string, len(r.Columns))
+		for j, column := range r.Columns {
+			values[j] = sanitizeCell(FieldString(rows.Index(i), column))
+		}
+		t.rows[i] = values
+	}
+
+	return t.render(p.w, p.width, p.color, !p.opts.NoHeaders)
+}
+
+// printDelimited writes rows as CSV or TSV with a header line
+func (p *Printer) printDelimited(r Result, comma rune) error {
+	rows, ok := r.rows()
+	if !ok {
+		return fmt.Errorf("output format %q requires a list result", p.opts.Format)
+	}
+
+	writer := csv.NewWriter(p.w)
+	writer.Comma = comma
+
+	if !p.opts.NoHeaders {
+		if err := writer.Write(r.Columns); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < rows.Len(); i++ {
+		values := make([]string, len(r.Columns))
+		for j, column := range r.Columns {
+			values[j] = FieldString(rows.Index(i), column)
+		}
+		if err := writer.Write(values); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// printTemplate executes the template once per row, or once for non-list results
+func (p *Printer) printTemplate(r Result) error {
+	execute := func(data any) error {
+		var buf bytes.Buffer
+		if err := p.tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to execute output template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		_, err := p.w.Write(buf.Bytes())
+		return err
+	}
+
+	rows, ok := r.rows()
+	if !ok {
+		return execute(r.Data)
+	}
+
+	for i := 0; i < rows.Len(); i++ {
+		row := rows.Index(i).Interface()
+		if projected, ok := row.(projectedRow); ok {
+			row = projected.asMap()
+		}
+		if err := execute(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printQuery writes each value selected by the query on its own line.
+// Strings are written raw, everything else as compact JSON.
+func (p *Printer) printQuery(data any) error {
+	values, err := p.query.Evaluate(data)
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			if _, err := fmt.Fprintln(p.w, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		jsonBytes, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		if _, err := fmt.Fprintln(p.w, string(jsonBytes)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// projectedRow is a record narrowed to selected columns, marshaled in column order
+type projectedRow struct {
+	columns []string
+	values  []any
+}
+
+// MarshalJSON writes the selected fields as an object, keeping the column order
+func (r projectedRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range r.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// field returns the value of a selected column
+func (r projectedRow) field(column string) (reflect.Value, bool) {
+	for i, name := range r.columns {
+		if name == column {
+			return indirect(reflect.ValueOf(r.values[i])), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// asMap returns the selected fields keyed by column, for use in templates
+func (r projectedRow) asMap() map[string]any {
+	m := make(map[string]any, len(r.columns))
+	for i, column := range r.columns {
+		m[column] = r.values[i]
+	}
+
+	return m
+}
+
+// projectRows narrows every record to the selected columns
+func projectRows(rows reflect.Value, columns []string) []projectedRow {
+	projected := make([]projectedRow, rows.Len())
+	for i := range projected {
+		values := make([]any, len(columns))
+		for j, column := range columns {
+			if value, ok := Field(rows.Index(i), column); ok && value.IsValid() {
+				values[j] = value.Interface()
+			}
+		}
+		projected[i] = projectedRow{columns: columns, values: values}
+	}
+
+	return projected
+}
+
+// sanitizeCell keeps a value on a single table line
+func sanitizeCell(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(value)
+}
@@ -0,0 +1,195 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// querySegmentKind identifies a step in a query path
+type querySegmentKind int
+
+const (
+	segmentKey querySegmentKind = iota
+	segmentIndex
+	segmentIterate
+)
+
+// querySegment is a single step in a query path
+type querySegment struct {
+	kind  querySegmentKind
+	key   string
+	index int
+}
+
+// Query is a compiled path expression selecting values from JSON output.
+// It accepts a jq-style subset such as ".Items[].Name" or ".[0].UserName",
+// and the JSONPath spelling "$.Items[*].Name".
+type Query struct {
+	expr     string
+	segments []querySegment
+}
+
+// ParseQuery compiles a query expression
+func ParseQuery(expr string) (*Query, error) {
+	q := &Query{expr: expr}
+
+	rest := strings.TrimSpace(expr)
+	rest = strings.TrimPrefix(rest, "$")
+	if rest == "" || rest == "." {
+		return q, nil
+	}
+
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query %q: missing ']'", expr)
+			}
+
+			segment, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+			}
+			q.segments = append(q.segments, segment)
+			rest = rest[end+1:]
+
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			if rest == "" || strings.HasPrefix(rest, "[") {
+				continue
+			}
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid query %q: empty field name", expr)
+			}
+			q.segments = append(q.segments, querySegment{kind: segmentKey, key: rest[:end]})
+			rest = rest[end:]
+
+		default:
+			return nil, fmt.Errorf("invalid query %q: expected '.' or '[' at %q", expr, rest)
+		}
+	}
+
+	return q, nil
+}
+
+// parseBracket parses the contents of a [...] query step
+func parseBracket(content string) (querySegment, error) {
+	content = strings.TrimSpace(content)
+
+	if content == "" || content == "*" {
+		return querySegment{kind: segmentIterate}, nil
+	}
+
+	if unquoted, err := strconv.Unquote(content); err == nil {
+		return querySegment{kind: segmentKey, key: unquoted}, nil
+	}
+	if len(content) >= 2 && content[0] == '\'' && content[len(content)-1] == '\'' {
+		return querySegment{kind: segmentKey, key: content[1 : len(content)-1]}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return querySegment{}, fmt.Errorf("invalid index %q", content)
+	}
+
+	return querySegment{kind: segmentIndex, index: index}, nil
+}
+
+// Evaluate applies the query to a value, returning every match.
+// The value is converted to its JSON form first, so field names match the JSON output.
+func (q *Query) Evaluate(data any) ([]any, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var root any
+	if err := json.Unmarshal(jsonBytes, &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	current := []any{root}
+	for _, segment := range q.segments {
+		next := make([]any, 0, len(current))
+		for _, value := range current {
+			matches, err := segment.apply(value)
+			if err != nil {
+				return nil, fmt.Errorf("query %q: %w", q.expr, err)
+			}
+			next = append(next, matches...)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// apply evaluates a single query step against a value
+func (s querySegment) apply(value any) ([]any, error) {
+	if value == nil {
+		return []any{nil}, nil
+	}
+
+	switch s.kind {
+	case segmentKey:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot index %s with %q", jsonKind(value), s.key)
+		}
+		return []any{m[s.key]}, nil
+
+	case segmentIndex:
+		list, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot index %s with %d", jsonKind(value), s.index)
+		}
+		index := s.index
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return []any{nil}, nil
+		}
+		return []any{list[index]}, nil
+
+	default:
+		switch v := value.(type) {
+		case []any:
+			return v, nil
+		case map[string]any:
+			values := make([]any, 0, len(v))
+			for _, item := range v {
+				values = append(values, item)
+			}
+			return values, nil
+		default:
+			return nil, fmt.Errorf("cannot iterate over %s", jsonKind(value))
+		}
+	}
+}
+
+// jsonKind names the JSON type of a decoded value for error messages
+func jsonKind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
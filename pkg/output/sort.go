@@ -0,0 +1,184 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// parseSortBy splits a sort flag value such as "Date-desc" into a column and direction
+func parseSortBy(value string) (column string, descending bool) {
+	if column, ok := strings.CutSuffix(value, "-desc"); ok {
+		return column, true
+	}
+	if column, ok := strings.CutSuffix(value, "-asc"); ok {
+		return column, false
+	}
+
+	return value, false
+}
+
+// sortRows sorts a slice of records in place by a column
+func sortRows(rows reflect.Value, sortBy string) error {
+	column, descending := parseSortBy(sortBy)
+	if err := checkColumns(rows.Type().Elem(), []string{column}); err != nil {
+		return err
+	}
+
+	keys := make([]reflect.Value, rows.Len())
+	for i := range keys {
+		keys[i], _ = Field(rows.Index(i), column)
+	}
+
+	// Sort an index so the keys and the rows can be swapped together
+	order := make([]int, rows.Len())
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if descending {
+			return compareValues(keys[order[j]], keys[order[i]]) < 0
+		}
+		return compareValues(keys[order[i]], keys[order[j]]) < 0
+	})
+
+	sorted := reflect.MakeSlice(reflect.SliceOf(rows.Type().Elem()), rows.Len(), rows.Len())
+	for i, from := range order {
+		sorted.Index(i).Set(rows.Index(from))
+	}
+	reflect.Copy(rows, sorted)
+
+	return nil
+}
+
+// compareValues orders two field values, treating missing values as smallest
+func compareValues(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+
+	switch {
+	case a.CanInt() && b.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint() && b.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanFloat() && b.CanFloat():
+		return cmp.Compare(a.Float(), b.Float())
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool()))
+	}
+
+	return strings.Compare(strings.ToLower(formatValue(a)), strings.ToLower(formatValue(b)))
+}
+
+// boolRank orders false before true
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// checkColumns verifies that every column names a field of the record type
+func checkColumns(t reflect.Type, columns []string) error {
+	for _, column := range columns {
+		if !hasField(t, column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	return nil
+}
+
+// hasField reports whether a record type has a field at a JSON path
+func hasField(t reflect.Type, path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := structFieldType(t, name)
+			if !ok {
+				if field, ok = methodType(t, name); !ok {
+					return false
+				}
+			}
+			t = field
+
+		case reflect.Map, reflect.Interface:
+			// Keys of maps and dynamic values can't be checked up front
+			return true
+
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// structFieldType finds the type of a struct field by its JSON name, searching embedded structs
+func structFieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == "-" {
+			continue
+		}
+
+		if field.Anonymous && tagName == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if found, ok := structFieldType(embedded, name); ok {
+					return found, true
+				}
+			}
+			continue
+		}
+
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if strings.EqualFold(tagName, name) {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// methodType finds the result type of a computed column method
+func methodType(t reflect.Type, name string) (reflect.Type, bool) {
+	for _, candidate := range []reflect.Type{t, reflect.PointerTo(t)} {
+		for i := 0; i < candidate.NumMethod(); i++ {
+			method := candidate.Method(i)
+			if strings.EqualFold(method.Name, name) && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+				return method.Type.Out(0), true
+			}
+		}
+	}
+
+	return nil, false
+}
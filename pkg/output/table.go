@@ -0,0 +1,258 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ColorMode controls when output is colorized
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"
+	ColorAlways ColorMode = "always"
+	ColorNever  ColorMode = "never"
+)
+
+// ParseColorMode parses a color flag value
+func ParseColorMode(value string) (ColorMode, error) {
+	switch mode := ColorMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return ColorAuto, nil
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid color mode %q: use auto, always or never", value)
+	}
+}
+
+// Color is an ANSI terminal color
+type Color string
+
+const (
+	ColorNone   Color = ""
+	ColorRed    Color = "\x1b[31m"
+	ColorGreen  Color = "\x1b[32m"
+	ColorYellow Color = "\x1b[33m"
+	ColorBlue   Color = "\x1b[34m"
+	ColorCyan   Color = "\x1b[36m"
+	ColorGray   Color = "\x1b[90m"
+	ColorBold   Color = "\x1b[1m"
+
+	colorReset = "\x1b[0m"
+)
+
+// StyleFunc chooses the color of a table cell from its value
+type StyleFunc func(value string) Color
+
+// SeverityStyle colors activity log severities
+func SeverityStyle(value string) Color {
+	switch strings.ToLower(value) {
+	case "error", "critical", "fatal":
+		return ColorRed
+	case "warn", "warning":
+		return ColorYellow
+	case "info", "information":
+		return ColorBlue
+	case "debug", "trace":
+		return ColorGray
+	default:
+		return ColorNone
+	}
+}
+
+// PlaybackStateStyle colors session playback states
+func PlaybackStateStyle(value string) Color {
+	switch strings.ToLower(value) {
+	case "playing", "active":
+		return ColorGreen
+	case "paused":
+		return ColorYellow
+	case "stopped":
+		return ColorRed
+	case "idle":
+		return ColorGray
+	default:
+		return ColorNone
+	}
+}
+
+// ChangeStyle colors the change markers shown in watch mode
+func ChangeStyle(value string) Color {
+	switch strings.ToLower(value) {
+	case "new", "started":
+		return ColorGreen
+	case "stopped", "removed":
+		return ColorRed
+	default:
+		return ColorNone
+	}
+}
+
+// IsTerminal reports whether w writes to a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// ColorEnabled decides whether output to w should be colorized.
+// In auto mode, color is used for terminals unless NO_COLOR is set.
+func ColorEnabled(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	return IsTerminal(w)
+}
+
+// TerminalWidth returns the width available to w, or 0 when it is unbounded.
+// Terminals report their size; otherwise the COLUMNS variable is used if set.
+func TerminalWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 0
+}
+
+// Colorize wraps text in a color when color is enabled
+func Colorize(text string, color Color, enabled bool) string {
+	if !enabled || color == ColorNone {
+		return text
+	}
+
+	return string(color) + text + colorReset
+}
+
+const (
+	// columnGap is the space between table columns
+	columnGap = 2
+
+	// minColumnWidth is the narrowest a column is truncated to
+	minColumnWidth = 6
+
+	// ellipsis marks truncated cells
+	ellipsis = "…"
+)
+
+// table is a grid of cells rendered with aligned, width-limited columns
+type table struct {
+	headers []string
+	rows    [][]string
+	styles  []StyleFunc
+}
+
+// render writes the table, truncating columns to fit maxWidth when it is positive
+func (t *table) render(w io.Writer, maxWidth int, color, headers bool) error {
+	widths := t.fitWidths(maxWidth, headers)
+
+	if headers {
+		if err := t.renderLine(w, t.headers, widths, func(int, string) Color { return ColorBold }, color); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range t.rows {
+		style := func(column int, value string) Color {
+			if t.styles[column] == nil {
+				return ColorNone
+			}
+			return t.styles[column](value)
+		}
+		if err := t.renderLine(w, row, widths, style, color); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderLine writes a single padded, truncated and colored line
+func (t *table) renderLine(w io.Writer, cells []string, widths []int, style func(int, string) Color, color bool) error {
+	var line strings.Builder
+	for i, cell := range cells {
+		text := truncate(cell, widths[i])
+		line.WriteString(Colorize(text, style(i, cell), color))
+
+		if i < len(cells)-1 {
+			line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(text)+columnGap))
+		}
+	}
+	line.WriteByte('\n')
+
+	_, err := io.WriteString(w, line.String())
+	return err
+}
+
+// fitWidths computes column widths, shrinking the widest columns until the table fits
+func (t *table) fitWidths(maxWidth int, headers bool) []int {
+	widths := make([]int, len(t.headers))
+	if headers {
+		for i, header := range t.headers {
+			widths[i] = utf8.RuneCountInString(header)
+		}
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	if maxWidth <= 0 {
+		return widths
+	}
+
+	total := columnGap * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+
+	for total > maxWidth {
+		widest := -1
+		for i, width := range widths {
+			if width > minColumnWidth && (widest < 0 || width > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+
+		widths[widest]--
+		total--
+	}
+
+	return widths
+}
+
+// truncate shortens text to width runes, marking the cut with an ellipsis
+func truncate(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	return string(runes[:width-1]) + ellipsis
+}